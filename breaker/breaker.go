@@ -0,0 +1,245 @@
+package breaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/azat-dev/go-utils/clock"
+	"github.com/azat-dev/go-utils/result"
+)
+
+// ErrCircuitOpen is returned in an Err Result when the breaker rejects a call without running it.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// State is the state of a CircuitBreaker.
+type State int
+
+const (
+	// Closed lets every call through and records its outcome.
+	Closed State = iota
+	// Open rejects every call with ErrCircuitOpen until the cooldown has passed.
+	Open
+	// HalfOpen lets a limited number of probe calls through to decide whether to close again.
+	HalfOpen
+)
+
+// String returns the lowercase name of the state.
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// Config controls when a CircuitBreaker opens and how it recovers.
+type Config struct {
+	// WindowSize is the number of most recent calls used to compute the failure ratio.
+	WindowSize int
+	// MinCalls is the number of calls the window must hold before the breaker can open.
+	MinCalls int
+	// FailureRatio opens the breaker when failures/calls in the window reaches it, from 0 to 1.
+	FailureRatio float64
+	// Cooldown is how long the breaker stays open before letting probe calls through.
+	Cooldown time.Duration
+	// HalfOpenCalls is the number of probe calls allowed in the half-open state.
+	// The breaker closes once all of them succeed and opens again on the first failure.
+	HalfOpenCalls int
+	// IsFailure reports whether an error counts as a failure. Nil means every error does.
+	IsFailure func(error) bool
+	// OnStateChange is called after every state change, outside of the breaker's lock. May be nil.
+	OnStateChange func(from, to State)
+	// Clock is used to measure the cooldown. Nil means clock.Real().
+	Clock clock.Clock
+}
+
+// DefaultConfig returns a Config that opens when half of the last 20 calls (at least 10) failed,
+// and probes with one call after a 30s cooldown.
+func DefaultConfig() Config {
+	return Config{
+		WindowSize:    20,
+		MinCalls:      10,
+		FailureRatio:  0.5,
+		Cooldown:      30 * time.Second,
+		HalfOpenCalls: 1,
+		IsFailure:     nil,
+		OnStateChange: nil,
+		Clock:         nil,
+	}
+}
+
+// CircuitBreaker stops calling a failing dependency for a while, so callers fail fast instead of piling up.
+// It is safe for concurrent use.
+type CircuitBreaker struct {
+	config Config
+	clock  clock.Clock
+
+	mu         sync.Mutex
+	state      State
+	generation uint64
+	window     []bool
+	next       int
+	failures   int
+	openedAt   time.Time
+	inFlight   int
+	successes  int
+}
+
+// New creates a closed CircuitBreaker.
+// Non-positive WindowSize and HalfOpenCalls are treated as 1, MinCalls is clamped to [1, WindowSize]
+// so the window can always reach it, and a FailureRatio outside (0, 1] is replaced by DefaultConfig's.
+func New(config Config) *CircuitBreaker {
+	config.WindowSize = max(config.WindowSize, 1)
+	config.HalfOpenCalls = max(config.HalfOpenCalls, 1)
+	config.MinCalls = min(max(config.MinCalls, 1), config.WindowSize)
+	if config.FailureRatio <= 0 || config.FailureRatio > 1 {
+		config.FailureRatio = DefaultConfig().FailureRatio
+	}
+	clk := config.Clock
+	if clk == nil {
+		clk = clock.Real()
+	}
+	return &CircuitBreaker{
+		config:     config,
+		clock:      clk,
+		mu:         sync.Mutex{},
+		state:      Closed,
+		generation: 0,
+		window:     make([]bool, 0, config.WindowSize),
+		next:       0,
+		failures:   0,
+		openedAt:   time.Time{},
+		inFlight:   0,
+		successes:  0,
+	}
+}
+
+// Execute runs f if the breaker allows it and records the outcome.
+// If the breaker is open, f is not called and an Err holding ErrCircuitOpen is returned.
+// A panic in f is recorded as a failure and then re-raised.
+//
+// Execute is a function rather than a method because Go methods can't have type parameters.
+func Execute[T any](cb *CircuitBreaker, f func() result.Result[T]) result.Result[T] {
+	generation, ok := cb.acquire()
+	if !ok {
+		return result.Err[T](ErrCircuitOpen)
+	}
+	failed := true
+	defer func() {
+		cb.release(generation, failed)
+	}()
+	r := f()
+	failed = r.IsErrAnd(cb.isFailure)
+	return r
+}
+
+// State returns the current state, moving from Open to HalfOpen if the cooldown has passed.
+func (cb *CircuitBreaker) State() State {
+	cb.mu.Lock()
+	from := cb.state
+	to := cb.refreshLocked()
+	cb.mu.Unlock()
+	cb.notify(from, to)
+	return to
+}
+
+func (cb *CircuitBreaker) isFailure(err error) bool {
+	return cb.config.IsFailure == nil || cb.config.IsFailure(err)
+}
+
+// acquire decides whether a call may run and returns the generation it belongs to.
+func (cb *CircuitBreaker) acquire() (uint64, bool) {
+	cb.mu.Lock()
+	from := cb.state
+	to := cb.refreshLocked()
+	allowed := true
+	switch to {
+	case Open:
+		allowed = false
+	case HalfOpen:
+		if cb.inFlight >= cb.config.HalfOpenCalls {
+			allowed = false
+		} else {
+			cb.inFlight++
+		}
+	}
+	generation := cb.generation
+	cb.mu.Unlock()
+	cb.notify(from, to)
+	return generation, allowed
+}
+
+// release records the outcome of a call. Outcomes of calls started before the last state change are ignored.
+func (cb *CircuitBreaker) release(generation uint64, failed bool) {
+	cb.mu.Lock()
+	from := cb.state
+	if generation == cb.generation {
+		switch cb.state {
+		case Closed:
+			cb.recordLocked(failed)
+			if len(cb.window) >= cb.config.MinCalls &&
+				float64(cb.failures)/float64(len(cb.window)) >= cb.config.FailureRatio {
+				cb.setStateLocked(Open)
+			}
+		case HalfOpen:
+			cb.inFlight--
+			if failed {
+				cb.setStateLocked(Open)
+			} else if cb.successes++; cb.successes >= cb.config.HalfOpenCalls {
+				cb.setStateLocked(Closed)
+			}
+		}
+	}
+	to := cb.state
+	cb.mu.Unlock()
+	cb.notify(from, to)
+}
+
+// recordLocked adds an outcome to the sliding window, overwriting the oldest one when it is full.
+func (cb *CircuitBreaker) recordLocked(failed bool) {
+	if len(cb.window) < cb.config.WindowSize {
+		cb.window = append(cb.window, failed)
+	} else {
+		if cb.window[cb.next] {
+			cb.failures--
+		}
+		cb.window[cb.next] = failed
+	}
+	cb.next = (cb.next + 1) % cb.config.WindowSize
+	if failed {
+		cb.failures++
+	}
+}
+
+// refreshLocked moves from Open to HalfOpen once the cooldown has passed, and returns the current state.
+func (cb *CircuitBreaker) refreshLocked() State {
+	if cb.state == Open && !cb.clock.Now().Before(cb.openedAt.Add(cb.config.Cooldown)) {
+		cb.setStateLocked(HalfOpen)
+	}
+	return cb.state
+}
+
+func (cb *CircuitBreaker) setStateLocked(state State) {
+	cb.state = state
+	cb.generation++
+	cb.window = cb.window[:0]
+	cb.next = 0
+	cb.failures = 0
+	cb.inFlight = 0
+	cb.successes = 0
+	if state == Open {
+		cb.openedAt = cb.clock.Now()
+	}
+}
+
+func (cb *CircuitBreaker) notify(from, to State) {
+	if from != to && cb.config.OnStateChange != nil {
+		cb.config.OnStateChange(from, to)
+	}
+}
@@ -0,0 +1,228 @@
+package breaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/azat-dev/go-utils/clock"
+	"github.com/azat-dev/go-utils/result"
+)
+
+var errDownstream = errors.New("downstream failed")
+
+type transition struct {
+	from, to State
+}
+
+func newTestBreaker() (*CircuitBreaker, *clock.Fake, *[]transition) {
+	fake := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	var transitions []transition
+	config := DefaultConfig()
+	config.WindowSize = 4
+	config.MinCalls = 4
+	config.FailureRatio = 0.5
+	config.Cooldown = time.Minute
+	config.HalfOpenCalls = 2
+	config.Clock = fake
+	config.OnStateChange = func(from, to State) {
+		transitions = append(transitions, transition{from: from, to: to})
+	}
+	return New(config), fake, &transitions
+}
+
+func succeed() result.Result[int] {
+	return result.Ok(1)
+}
+
+func fail() result.Result[int] {
+	return result.Err[int](errDownstream)
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	cb, fake, transitions := newTestBreaker()
+
+	// Fewer calls than MinCalls never open the breaker.
+	Execute(cb, fail)
+	Execute(cb, fail)
+	Execute(cb, succeed)
+	if cb.State() != Closed {
+		t.Fatalf("Expected closed below MinCalls, got %v", cb.State())
+	}
+
+	// The fourth call fills the window with a failure ratio of 3/4.
+	r := Execute(cb, fail)
+	if !r.ErrIs(errDownstream) {
+		t.Errorf("Expected the call's own error, got %v", r)
+	}
+	if cb.State() != Open {
+		t.Fatalf("Expected open, got %v", cb.State())
+	}
+
+	called := false
+	r = Execute(cb, func() result.Result[int] { called = true; return result.Ok(1) })
+	if called {
+		t.Error("Expected open breaker not to call f")
+	}
+	if !r.ErrIs(ErrCircuitOpen) {
+		t.Errorf("Expected ErrCircuitOpen, got %v", r)
+	}
+
+	fake.Advance(time.Minute)
+	if cb.State() != HalfOpen {
+		t.Fatalf("Expected half-open after cooldown, got %v", cb.State())
+	}
+
+	Execute(cb, succeed)
+	if cb.State() != HalfOpen {
+		t.Fatalf("Expected half-open until all probes succeed, got %v", cb.State())
+	}
+	Execute(cb, succeed)
+	if cb.State() != Closed {
+		t.Fatalf("Expected closed after successful probes, got %v", cb.State())
+	}
+
+	expected := []transition{{Closed, Open}, {Open, HalfOpen}, {HalfOpen, Closed}}
+	if len(*transitions) != len(expected) {
+		t.Fatalf("Expected transitions %v, got %v", expected, *transitions)
+	}
+	for i, tr := range expected {
+		if (*transitions)[i] != tr {
+			t.Errorf("Expected transition %v at %d, got %v", tr, i, (*transitions)[i])
+		}
+	}
+}
+
+func TestHalfOpenFailure(t *testing.T) {
+	cb, fake, _ := newTestBreaker()
+	for range 4 {
+		Execute(cb, fail)
+	}
+	fake.Advance(time.Minute)
+
+	Execute(cb, fail)
+	if cb.State() != Open {
+		t.Fatalf("Expected failed probe to reopen, got %v", cb.State())
+	}
+
+	fake.Advance(30 * time.Second)
+	if cb.State() != Open {
+		t.Errorf("Expected cooldown to restart, got %v", cb.State())
+	}
+}
+
+func TestHalfOpenLimitsProbes(t *testing.T) {
+	cb, fake, _ := newTestBreaker()
+	for range 4 {
+		Execute(cb, fail)
+	}
+	fake.Advance(time.Minute)
+
+	// Two probes are in flight, so a third call is rejected.
+	release := make(chan struct{})
+	done := make(chan struct{})
+	started := make(chan struct{}, 2)
+	for range 2 {
+		go func() {
+			Execute(cb, func() result.Result[int] {
+				started <- struct{}{}
+				<-release
+				return result.Ok(1)
+			})
+			done <- struct{}{}
+		}()
+	}
+	<-started
+	<-started
+	if !Execute(cb, succeed).ErrIs(ErrCircuitOpen) {
+		t.Error("Expected extra probe to be rejected")
+	}
+	close(release)
+	<-done
+	<-done
+	if cb.State() != Closed {
+		t.Errorf("Expected closed after probes, got %v", cb.State())
+	}
+}
+
+func TestSlidingWindow(t *testing.T) {
+	cb, _, _ := newTestBreaker()
+	// Old failures slide out of the window, so the ratio never reaches 1/2.
+	for range 3 {
+		Execute(cb, fail)
+		Execute(cb, succeed)
+		Execute(cb, succeed)
+		Execute(cb, succeed)
+	}
+	if cb.State() != Closed {
+		t.Errorf("Expected closed, got %v", cb.State())
+	}
+}
+
+func TestIsFailure(t *testing.T) {
+	ignored := errors.New("ignored")
+	config := DefaultConfig()
+	config.WindowSize = 2
+	config.MinCalls = 2
+	config.IsFailure = func(err error) bool { return !errors.Is(err, ignored) }
+	cb := New(config)
+	for range 5 {
+		Execute(cb, func() result.Result[int] { return result.Err[int](ignored) })
+	}
+	if cb.State() != Closed {
+		t.Errorf("Expected ignored errors not to open the breaker, got %v", cb.State())
+	}
+}
+
+func TestPanicIsRecordedAsFailure(t *testing.T) {
+	config := DefaultConfig()
+	config.WindowSize = 1
+	config.MinCalls = 1
+	cb := New(config)
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected panic to be re-raised")
+			}
+		}()
+		Execute(cb, func() result.Result[int] { panic("boom") })
+	}()
+	if cb.State() != Open {
+		t.Errorf("Expected panic to open the breaker, got %v", cb.State())
+	}
+}
+
+func TestNewNormalizesConfig(t *testing.T) {
+	t.Run("zero FailureRatio doesn't open on success", func(t *testing.T) {
+		cb := New(Config{})
+		Execute(cb, succeed)
+		if cb.State() != Closed {
+			t.Errorf("Expected closed after a successful call, got %v", cb.State())
+		}
+		Execute(cb, fail)
+		// With a zero Cooldown the open breaker moves on to half-open at once.
+		if cb.State() == Closed {
+			t.Error("Expected the default ratio to open the breaker on a failed call")
+		}
+	})
+
+	t.Run("MinCalls above WindowSize", func(t *testing.T) {
+		config := DefaultConfig()
+		config.WindowSize = 2
+		config.MinCalls = 10
+		cb := New(config)
+		Execute(cb, fail)
+		Execute(cb, fail)
+		if cb.State() != Open {
+			t.Errorf("Expected MinCalls to be clamped to the window and the breaker to open, got %v", cb.State())
+		}
+	})
+}
+
+func TestStateString(t *testing.T) {
+	for state, name := range map[State]string{Closed: "closed", Open: "open", HalfOpen: "half-open", State(9): "unknown"} {
+		if state.String() != name {
+			t.Errorf("Expected '%s', got '%s'", name, state.String())
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReal(t *testing.T) {
+	c := Real()
+	before := time.Now()
+	if c.Now().Before(before) {
+		t.Error("Expected Now to return the current time")
+	}
+	select {
+	case <-c.After(time.Millisecond):
+	case <-time.After(time.Second):
+		t.Error("Expected After to fire")
+	}
+}
+
+func TestFake(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewFake(start)
+
+	if !c.Now().Equal(start) {
+		t.Errorf("Expected %v, got %v", start, c.Now())
+	}
+
+	ch := c.After(time.Second)
+	if c.Waiters() != 1 {
+		t.Errorf("Expected 1 waiter, got %d", c.Waiters())
+	}
+
+	c.Advance(500 * time.Millisecond)
+	select {
+	case <-ch:
+		t.Fatal("Expected After not to fire before its deadline")
+	default:
+	}
+
+	c.Advance(500 * time.Millisecond)
+	select {
+	case now := <-ch:
+		if !now.Equal(start.Add(time.Second)) {
+			t.Errorf("Expected %v, got %v", start.Add(time.Second), now)
+		}
+	default:
+		t.Fatal("Expected After to fire at its deadline")
+	}
+	if c.Waiters() != 0 {
+		t.Errorf("Expected no waiters, got %d", c.Waiters())
+	}
+
+	select {
+	case <-c.After(0):
+	default:
+		t.Error("Expected After(0) to fire immediately")
+	}
+
+	later := start.Add(time.Hour)
+	c.Set(later)
+	if !c.Now().Equal(later) {
+		t.Errorf("Expected %v, got %v", later, c.Now())
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import "testing"
+
+func TestUnifiedDiff(t *testing.T) {
+	t.Run("equal", func(t *testing.T) {
+		if got := unifiedDiff("f.go", "a\nb\n", "a\nb\n"); got != "" {
+			t.Errorf("Expected empty diff, got %q", got)
+		}
+	})
+
+	t.Run("one change", func(t *testing.T) {
+		old := "1\n2\n3\n4\n5\n6\n7\n8\n"
+		new := "1\n2\n3\n4\nfive\n6\n7\n8\n"
+		want := "--- a/f.go\n+++ b/f.go\n@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n"
+		if got := unifiedDiff("f.go", old, new); got != want {
+			t.Errorf("Expected:\n%s\ngot:\n%s", want, got)
+		}
+	})
+
+	t.Run("separate hunks", func(t *testing.T) {
+		old := "a\n1\n2\n3\n4\n5\n6\n7\nb\n"
+		new := "A\n1\n2\n3\n4\n5\n6\n7\nB\n"
+		want := "--- a/f.go\n+++ b/f.go\n@@ -1,4 +1,4 @@\n-a\n+A\n 1\n 2\n 3\n@@ -6,4 +6,4 @@\n 5\n 6\n 7\n-b\n+B\n"
+		if got := unifiedDiff("f.go", old, new); got != want {
+			t.Errorf("Expected:\n%s\ngot:\n%s", want, got)
+		}
+	})
+
+	t.Run("insertion into empty file", func(t *testing.T) {
+		want := "--- a/f.go\n+++ b/f.go\n@@ -0,0 +1,1 @@\n+x\n"
+		if got := unifiedDiff("f.go", "", "x\n"); got != want {
+			t.Errorf("Expected:\n%s\ngot:\n%s", want, got)
+		}
+	})
+}
+
+func TestEditor(t *testing.T) {
+	t.Run("insertions before replacement at same offset", func(t *testing.T) {
+		var ed editor
+		ed.replace(4, 5, "X")
+		ed.insert(4, "[")
+		ed.insert(4, "(")
+		got, err := ed.apply([]byte("abcdefg"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != "abcd[(Xfg" {
+			t.Errorf("Expected 'abcd[(Xfg', got '%s'", got)
+		}
+	})
+
+	t.Run("overlapping replacements", func(t *testing.T) {
+		var ed editor
+		ed.replace(1, 4, "x")
+		ed.replace(2, 5, "y")
+		if _, err := ed.apply([]byte("abcdefg")); err == nil {
+			t.Error("Expected an error for overlapping edits")
+		}
+	})
+}
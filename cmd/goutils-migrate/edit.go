@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// edit replaces the source bytes in [start, end) with text. An insertion has start == end.
+type edit struct {
+	start, end int
+	text       string
+	seq        int
+}
+
+// editor collects non-overlapping edits of one file and applies them in a single pass,
+// so rewrites of nested expressions compose without re-parsing.
+type editor struct {
+	edits []edit
+}
+
+func (e *editor) replace(start, end int, text string) {
+	e.edits = append(e.edits, edit{start: start, end: end, text: text, seq: len(e.edits)})
+}
+
+func (e *editor) insert(at int, text string) {
+	e.replace(at, at, text)
+}
+
+func (e *editor) empty() bool {
+	return len(e.edits) == 0
+}
+
+// apply returns src with all edits applied. At the same offset, insertions go before replacements,
+// in the order they were added. Overlapping replacements are an error.
+func (e *editor) apply(src []byte) ([]byte, error) {
+	edits := append([]edit(nil), e.edits...)
+	sort.SliceStable(edits, func(i, j int) bool {
+		a, b := edits[i], edits[j]
+		if a.start != b.start {
+			return a.start < b.start
+		}
+		if (a.start == a.end) != (b.start == b.end) {
+			return a.start == a.end
+		}
+		return a.seq < b.seq
+	})
+
+	out := make([]byte, 0, len(src))
+	last := 0
+	for _, ed := range edits {
+		if ed.start < last {
+			return nil, fmt.Errorf("overlapping edits at offset %d", ed.start)
+		}
+		out = append(out, src[last:ed.start]...)
+		out = append(out, ed.text...)
+		last = ed.end
+	}
+	out = append(out, src[last:]...)
+	return out, nil
+}
@@ -0,0 +1,827 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"go/types"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	optionalPath = "github.com/azat-dev/go-utils/optional"
+	resultPath   = "github.com/azat-dev/go-utils/result"
+)
+
+// Options selects what Migrate rewrites.
+type Options struct {
+	// Fields lists struct fields to turn from *T into optional.Optional[T], as "Type.Field".
+	Fields []string
+	// Funcs lists functions returning (T, error) to turn into result.Result[T], as "Func" or "Type.Method".
+	Funcs []string
+}
+
+// Change is the rewritten content of one file.
+type Change struct {
+	Path string
+	Old  []byte
+	New  []byte
+}
+
+// Warning is a use of a migrated field or function the tool couldn't rewrite safely.
+type Warning struct {
+	Pos     token.Position
+	Message string
+}
+
+// String formats the warning as "file:line:col: message".
+func (w Warning) String() string {
+	return fmt.Sprintf("%s: %s", w.Pos, w.Message)
+}
+
+// pkgInfo is one parsed and type-checked package.
+type pkgInfo struct {
+	dir   string
+	fset  *token.FileSet
+	files []*ast.File
+	names []string
+	pkg   *types.Package
+	info  *types.Info
+}
+
+// Migrate rewrites the packages in dirs according to opts and returns the changed files.
+// Field and function names are resolved in the scope of each package in dirs; declarations must be in one of them.
+func Migrate(dirs []string, opts Options) ([]Change, []Warning, error) {
+	pkgs := make([]*pkgInfo, 0, len(dirs))
+	for _, dir := range dirs {
+		p, err := loadPackage(dir)
+		if err != nil {
+			return nil, nil, err
+		}
+		pkgs = append(pkgs, p)
+	}
+
+	m := &migration{
+		fieldKeys: make(map[string]bool),
+		funcKeys:  make(map[string]bool),
+		warnings:  nil,
+	}
+	if err := m.resolveTargets(pkgs, opts); err != nil {
+		return nil, nil, err
+	}
+
+	var changes []Change
+	for _, p := range pkgs {
+		pkgChanges, err := m.rewritePackage(p)
+		if err != nil {
+			return nil, nil, err
+		}
+		changes = append(changes, pkgChanges...)
+	}
+	sort.Slice(m.warnings, func(i, j int) bool {
+		a, b := m.warnings[i].Pos, m.warnings[j].Pos
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		return a.Offset < b.Offset
+	})
+	return changes, m.warnings, nil
+}
+
+func loadPackage(dir string) (*pkgInfo, error) {
+	buildPkg, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, fmt.Errorf("goutils-migrate: %w", err)
+	}
+	importPath, err := listImportPath(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &pkgInfo{
+		dir:   dir,
+		fset:  token.NewFileSet(),
+		files: nil,
+		names: nil,
+		pkg:   nil,
+		info: &types.Info{
+			Types:      make(map[ast.Expr]types.TypeAndValue),
+			Defs:       make(map[*ast.Ident]types.Object),
+			Uses:       make(map[*ast.Ident]types.Object),
+			Selections: make(map[*ast.SelectorExpr]*types.Selection),
+		},
+	}
+	for _, name := range buildPkg.GoFiles {
+		path := filepath.Join(dir, name)
+		file, err := parser.ParseFile(p.fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return nil, fmt.Errorf("goutils-migrate: %w", err)
+		}
+		p.files = append(p.files, file)
+		p.names = append(p.names, path)
+	}
+
+	config := types.Config{Importer: importer.ForCompiler(p.fset, "source", nil)}
+	p.pkg, err = config.Check(importPath, p.fset, p.files, p.info)
+	if err != nil {
+		return nil, fmt.Errorf("goutils-migrate: %w", err)
+	}
+	return p, nil
+}
+
+// listImportPath asks the go command for the import path of the package in dir,
+// so objects declared there match the ones seen through imports from other packages.
+func listImportPath(dir string) (string, error) {
+	cmd := exec.Command("go", "list", "-f", "{{.ImportPath}}", ".")
+	cmd.Dir = dir
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("goutils-migrate: go list %s: %w", dir, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// migration holds the resolved targets and the warnings collected while rewriting.
+type migration struct {
+	// fieldKeys and funcKeys identify targets across packages as "importpath.Type.Field",
+	// "importpath.Func" or "importpath.Type.Method".
+	fieldKeys map[string]bool
+	funcKeys  map[string]bool
+	warnings  []Warning
+}
+
+func (m *migration) warn(p *pkgInfo, pos token.Pos, format string, args ...any) {
+	m.warnings = append(m.warnings, Warning{Pos: p.fset.Position(pos), Message: fmt.Sprintf(format, args...)})
+}
+
+// resolveTargets finds the declarations of the requested fields and functions and checks that they can be migrated.
+func (m *migration) resolveTargets(pkgs []*pkgInfo, opts Options) error {
+	for _, spec := range opts.Fields {
+		typeName, fieldName, ok := strings.Cut(spec, ".")
+		if !ok {
+			return fmt.Errorf("goutils-migrate: field %q must be written as Type.Field", spec)
+		}
+		found := false
+		for _, p := range pkgs {
+			v := lookupField(p.pkg, typeName, fieldName)
+			if v == nil {
+				continue
+			}
+			if _, ok := v.Type().(*types.Pointer); !ok {
+				return fmt.Errorf("goutils-migrate: field %s is not a pointer", spec)
+			}
+			m.fieldKeys[p.pkg.Path()+"."+spec] = true
+			found = true
+		}
+		if !found {
+			return fmt.Errorf("goutils-migrate: field %s not found", spec)
+		}
+	}
+
+	for _, spec := range opts.Funcs {
+		found := false
+		for _, p := range pkgs {
+			fn := lookupFunc(p.pkg, spec)
+			if fn == nil {
+				continue
+			}
+			if _, ok := resultType(fn); !ok {
+				return fmt.Errorf("goutils-migrate: %s does not return (T, error)", spec)
+			}
+			if decl := findFuncDecl(p, fn); decl != nil && decl.Type.Results.List[0].Names != nil {
+				return fmt.Errorf("goutils-migrate: %s has named results, which are not supported", spec)
+			}
+			m.funcKeys[p.pkg.Path()+"."+spec] = true
+			found = true
+		}
+		if !found {
+			return fmt.Errorf("goutils-migrate: function %s not found", spec)
+		}
+	}
+	return nil
+}
+
+func lookupField(pkg *types.Package, typeName, fieldName string) *types.Var {
+	obj, ok := pkg.Scope().Lookup(typeName).(*types.TypeName)
+	if !ok {
+		return nil
+	}
+	st, ok := obj.Type().Underlying().(*types.Struct)
+	if !ok {
+		return nil
+	}
+	for i := range st.NumFields() {
+		if st.Field(i).Name() == fieldName {
+			return st.Field(i)
+		}
+	}
+	return nil
+}
+
+func lookupFunc(pkg *types.Package, spec string) *types.Func {
+	typeName, method, isMethod := strings.Cut(spec, ".")
+	if !isMethod {
+		fn, _ := pkg.Scope().Lookup(spec).(*types.Func)
+		return fn
+	}
+	obj, ok := pkg.Scope().Lookup(typeName).(*types.TypeName)
+	if !ok {
+		return nil
+	}
+	sel, _, _ := types.LookupFieldOrMethod(types.NewPointer(obj.Type()), false, pkg, method)
+	fn, _ := sel.(*types.Func)
+	return fn
+}
+
+func findFuncDecl(p *pkgInfo, fn *types.Func) *ast.FuncDecl {
+	for _, file := range p.files {
+		for _, decl := range file.Decls {
+			if fd, ok := decl.(*ast.FuncDecl); ok && p.info.Defs[fd.Name] == fn {
+				return fd
+			}
+		}
+	}
+	return nil
+}
+
+// resultType returns T if fn returns (T, error).
+func resultType(fn *types.Func) (types.Type, bool) {
+	results := fn.Type().(*types.Signature).Results()
+	if results.Len() != 2 || !types.Identical(results.At(1).Type(), types.Universe.Lookup("error").Type()) {
+		return nil, false
+	}
+	return results.At(0).Type(), true
+}
+
+// fieldKey returns the target key of a struct field object, or "" if v is not a field.
+func fieldKey(v *types.Var) string {
+	if v == nil || !v.IsField() || v.Pkg() == nil {
+		return ""
+	}
+	// A field object doesn't know its struct, so search the named types of its package.
+	scope := v.Pkg().Scope()
+	for _, name := range scope.Names() {
+		obj, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok {
+			continue
+		}
+		st, ok := obj.Type().Underlying().(*types.Struct)
+		if !ok {
+			continue
+		}
+		for i := range st.NumFields() {
+			if st.Field(i) == v {
+				return v.Pkg().Path() + "." + name + "." + v.Name()
+			}
+		}
+	}
+	return ""
+}
+
+// funcKey returns the target key of a function or method object.
+func funcKey(fn *types.Func) string {
+	if fn.Pkg() == nil {
+		return ""
+	}
+	recv := fn.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return fn.Pkg().Path() + "." + fn.Name()
+	}
+	t := recv.Type()
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := t.(*types.Named)
+	if !ok {
+		return ""
+	}
+	return fn.Pkg().Path() + "." + named.Obj().Name() + "." + fn.Name()
+}
+
+// fileRewriter rewrites one file of a package.
+type fileRewriter struct {
+	m       *migration
+	p       *pkgInfo
+	file    *ast.File
+	src     []byte
+	ed      editor
+	handled map[ast.Node]bool
+	// written holds the dereferences that are assigned to, incremented or have their address taken.
+	written map[ast.Expr]bool
+	// imports records the packages referenced by the inserted code.
+	imports map[string]bool
+}
+
+func (m *migration) rewritePackage(p *pkgInfo) ([]Change, error) {
+	var changes []Change
+	for i, file := range p.files {
+		src, err := os.ReadFile(p.names[i])
+		if err != nil {
+			return nil, fmt.Errorf("goutils-migrate: %w", err)
+		}
+		r := &fileRewriter{
+			m:       m,
+			p:       p,
+			file:    file,
+			src:     src,
+			ed:      editor{edits: nil},
+			handled: make(map[ast.Node]bool),
+			written: make(map[ast.Expr]bool),
+			imports: make(map[string]bool),
+		}
+		r.rewrite()
+		if r.ed.empty() {
+			continue
+		}
+		out, err := r.ed.apply(src)
+		if err != nil {
+			return nil, fmt.Errorf("goutils-migrate: %s: %w", p.names[i], err)
+		}
+		out, err = addImports(out, r.imports)
+		if err != nil {
+			return nil, fmt.Errorf("goutils-migrate: %s: %w", p.names[i], err)
+		}
+		changes = append(changes, Change{Path: p.names[i], Old: src, New: out})
+	}
+	return changes, nil
+}
+
+func (r *fileRewriter) offset(pos token.Pos) int {
+	return r.p.fset.Position(pos).Offset
+}
+
+func (r *fileRewriter) text(node ast.Node) string {
+	return string(r.src[r.offset(node.Pos()):r.offset(node.End())])
+}
+
+// typeString prints t as it would be written in this file.
+func (r *fileRewriter) typeString(t types.Type) string {
+	return types.TypeString(t, func(pkg *types.Package) string {
+		if pkg == r.p.pkg {
+			return ""
+		}
+		for _, spec := range r.file.Imports {
+			path, _ := strconv.Unquote(spec.Path.Value)
+			if path == pkg.Path() && spec.Name != nil {
+				return spec.Name.Name
+			}
+		}
+		return pkg.Name()
+	})
+}
+
+// printExpr renders a synthesized expression with go/printer.
+func printExpr(expr ast.Expr) string {
+	var buf bytes.Buffer
+	_ = printer.Fprint(&buf, token.NewFileSet(), expr)
+	return buf.String()
+}
+
+// call builds the expression pkg.name[typeArgs](args...) for printing.
+func call(pkg, name, typeArg string, args ...ast.Expr) ast.Expr {
+	var fun ast.Expr = &ast.SelectorExpr{X: ast.NewIdent(pkg), Sel: ast.NewIdent(name)}
+	if typeArg != "" {
+		fun = &ast.IndexExpr{X: fun, Index: ast.NewIdent(typeArg)}
+	}
+	return &ast.CallExpr{Fun: fun, Args: args}
+}
+
+// callPrefix returns the text opening a call to pkg.name[typeArg], without the arguments and closing paren.
+func callPrefix(pkg, name, typeArg string) string {
+	s := printExpr(call(pkg, name, typeArg))
+	return strings.TrimSuffix(s, ")")
+}
+
+func (r *fileRewriter) targetField(sel *ast.SelectorExpr) bool {
+	v, _ := r.p.info.Uses[sel.Sel].(*types.Var)
+	return r.m.fieldKeys[fieldKey(v)]
+}
+
+func (r *fileRewriter) targetFieldIdent(id *ast.Ident) bool {
+	v, _ := r.p.info.Uses[id].(*types.Var)
+	return r.m.fieldKeys[fieldKey(v)]
+}
+
+// targetCall returns the migrated function called by call, if any.
+func (r *fileRewriter) targetCall(expr ast.Expr) (*types.Func, bool) {
+	c, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok {
+		return nil, false
+	}
+	var id *ast.Ident
+	switch fun := ast.Unparen(c.Fun).(type) {
+	case *ast.Ident:
+		id = fun
+	case *ast.SelectorExpr:
+		id = fun.Sel
+	default:
+		return nil, false
+	}
+	fn, ok := r.p.info.Uses[id].(*types.Func)
+	if !ok {
+		return nil, false
+	}
+	return fn, r.m.funcKeys[funcKey(fn)]
+}
+
+func (r *fileRewriter) rewrite() {
+	for _, decl := range r.file.Decls {
+		if fd, ok := decl.(*ast.FuncDecl); ok {
+			if fn, ok := r.p.info.Defs[fd.Name].(*types.Func); ok && r.m.funcKeys[funcKey(fn)] {
+				r.rewriteFuncDecl(fd, fn)
+			}
+		}
+	}
+
+	ast.Inspect(r.file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.Field:
+			r.rewriteFieldDecl(n)
+		case *ast.BinaryExpr:
+			r.rewriteNilCheck(n)
+		case *ast.StarExpr:
+			r.rewriteDeref(n)
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				r.written[ast.Unparen(lhs)] = true
+			}
+			r.rewriteAssign(n)
+		case *ast.IncDecStmt:
+			r.written[ast.Unparen(n.X)] = true
+		case *ast.UnaryExpr:
+			if n.Op == token.AND {
+				r.written[ast.Unparen(n.X)] = true
+			}
+		case *ast.KeyValueExpr:
+			r.rewriteKeyValue(n)
+		case *ast.GoStmt:
+			r.warnDeferredCall(n.Call, "go")
+		case *ast.DeferStmt:
+			r.warnDeferredCall(n.Call, "defer")
+		case *ast.CallExpr:
+			r.rewriteCallSite(n)
+		case *ast.SelectorExpr:
+			r.checkFieldUse(n)
+		case *ast.Ident:
+			r.checkFuncValue(n)
+		}
+		return true
+	})
+}
+
+// rewriteFieldDecl turns "F *T" into "F optional.Optional[T]" for target fields.
+func (r *fileRewriter) rewriteFieldDecl(f *ast.Field) {
+	star, ok := f.Type.(*ast.StarExpr)
+	if !ok {
+		return
+	}
+	for _, name := range f.Names {
+		v, _ := r.p.info.Defs[name].(*types.Var)
+		if !r.m.fieldKeys[fieldKey(v)] {
+			continue
+		}
+		if len(f.Names) > 1 {
+			r.m.warn(r.p, name.Pos(), "field %s is declared together with other fields; split the declaration", name.Name)
+			return
+		}
+		r.imports[optionalPath] = true
+		r.ed.replace(r.offset(f.Type.Pos()), r.offset(f.Type.End()), "optional.Optional["+r.text(star.X)+"]")
+	}
+}
+
+// rewriteNilCheck turns "x.F == nil" into "x.F.IsNone()" and "x.F != nil" into "x.F.IsSome()".
+func (r *fileRewriter) rewriteNilCheck(b *ast.BinaryExpr) {
+	if b.Op != token.EQL && b.Op != token.NEQ {
+		return
+	}
+	method := ".IsNone()"
+	if b.Op == token.NEQ {
+		method = ".IsSome()"
+	}
+	if sel, ok := ast.Unparen(b.X).(*ast.SelectorExpr); ok && r.targetField(sel) && r.isNil(b.Y) {
+		r.handled[sel] = true
+		r.ed.replace(r.offset(b.X.End()), r.offset(b.Y.End()), method)
+		return
+	}
+	if sel, ok := ast.Unparen(b.Y).(*ast.SelectorExpr); ok && r.targetField(sel) && r.isNil(b.X) {
+		r.handled[sel] = true
+		r.ed.replace(r.offset(b.X.Pos()), r.offset(b.Y.Pos()), "")
+		r.ed.insert(r.offset(b.Y.End()), method)
+	}
+}
+
+// rewriteDeref turns "*x.F" into "x.F.Unwrap()".
+// Writes through the pointer can't be expressed on an Optional and are reported instead.
+func (r *fileRewriter) rewriteDeref(star *ast.StarExpr) {
+	sel, ok := ast.Unparen(star.X).(*ast.SelectorExpr)
+	if !ok || !r.targetField(sel) {
+		return
+	}
+	if _, isType := r.p.info.Types[star]; isType && r.p.info.Types[star].IsType() {
+		return
+	}
+	r.handled[sel] = true
+	if r.written[star] {
+		r.m.warn(r.p, star.Pos(), "%s is written through the pointer; rewrite this use by hand", r.text(star))
+		return
+	}
+	r.ed.replace(r.offset(star.Star), r.offset(star.Star)+1, "")
+	r.ed.insert(r.offset(star.X.End()), ".Unwrap()")
+}
+
+// rewriteAssign rewrites values assigned to target fields.
+func (r *fileRewriter) rewriteAssign(a *ast.AssignStmt) {
+	if len(a.Lhs) != len(a.Rhs) {
+		return
+	}
+	for i, lhs := range a.Lhs {
+		sel, ok := ast.Unparen(lhs).(*ast.SelectorExpr)
+		if !ok || !r.targetField(sel) {
+			continue
+		}
+		r.handled[sel] = true
+		r.rewriteValue(sel.Sel, a.Rhs[i])
+	}
+}
+
+// rewriteKeyValue rewrites target fields set in composite literals.
+func (r *fileRewriter) rewriteKeyValue(kv *ast.KeyValueExpr) {
+	key, ok := kv.Key.(*ast.Ident)
+	if !ok || !r.targetFieldIdent(key) {
+		return
+	}
+	r.rewriteValue(key, kv.Value)
+}
+
+// rewriteValue converts a *T value stored into a target field:
+// nil becomes None, &v becomes Some(v) and other pointers go through NewFromNullablePointer.
+func (r *fileRewriter) rewriteValue(field *ast.Ident, value ast.Expr) {
+	if sel, ok := ast.Unparen(value).(*ast.SelectorExpr); ok && r.targetField(sel) {
+		// Copying one migrated field into another needs no conversion.
+		r.handled[sel] = true
+		return
+	}
+	r.imports[optionalPath] = true
+	if r.isNil(value) {
+		elem := r.p.info.Uses[field].Type().(*types.Pointer).Elem()
+		r.ed.replace(r.offset(value.Pos()), r.offset(value.End()), printExpr(call("optional", "None", r.typeString(elem))))
+		return
+	}
+	if unary, ok := ast.Unparen(value).(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		r.ed.replace(r.offset(unary.OpPos), r.offset(unary.X.Pos()), callPrefix("optional", "Some", ""))
+		r.ed.insert(r.offset(unary.X.End()), ")")
+		return
+	}
+	r.ed.insert(r.offset(value.Pos()), callPrefix("optional", "NewFromNullablePointer", ""))
+	r.ed.insert(r.offset(value.End()), ")")
+}
+
+func (r *fileRewriter) isNil(expr ast.Expr) bool {
+	id, ok := ast.Unparen(expr).(*ast.Ident)
+	return ok && r.p.info.Uses[id] == types.Universe.Lookup("nil")
+}
+
+// checkFieldUse warns about target field uses that were not rewritten,
+// such as passing the pointer on or calling its methods.
+func (r *fileRewriter) checkFieldUse(sel *ast.SelectorExpr) {
+	if r.handled[sel] || !r.targetField(sel) {
+		return
+	}
+	r.m.warn(r.p, sel.Pos(), "%s is used as a pointer; rewrite this use by hand", r.text(sel))
+}
+
+// rewriteFuncDecl changes the results of a migrated function and rewrites its return statements.
+func (r *fileRewriter) rewriteFuncDecl(fd *ast.FuncDecl, fn *types.Func) {
+	if fd.Body == nil {
+		return
+	}
+	t, _ := resultType(fn)
+	results := fd.Type.Results
+	r.imports[resultPath] = true
+	r.ed.replace(r.offset(results.Pos()), r.offset(results.End()), "result.Result["+r.typeString(t)+"]")
+
+	ast.Inspect(fd.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			r.rewriteReturn(n, t)
+		}
+		return true
+	})
+}
+
+// rewriteReturn rewrites "return v, nil" to Ok, "return zero, err" to Err and everything else to From.
+// Ok panics on nil, so "return v, nil" with a v that may be nil is reported and left unchanged:
+// it succeeds today, and no Result constructor keeps it a success.
+func (r *fileRewriter) rewriteReturn(ret *ast.ReturnStmt, t types.Type) {
+	switch len(ret.Results) {
+	case 1:
+		if _, ok := r.targetCall(ret.Results[0]); ok {
+			r.handled[ast.Unparen(ret.Results[0])] = true
+			return
+		}
+		r.ed.insert(r.offset(ret.Results[0].Pos()), callPrefix("result", "From", ""))
+		r.ed.insert(r.offset(ret.Results[0].End()), ")")
+	case 2:
+		value, err := ret.Results[0], ret.Results[1]
+		switch {
+		case r.isNil(err) && r.isNil(value):
+			r.m.warn(r.p, value.Pos(), "nil returned without an error has no Result equivalent; rewrite this return by hand")
+		case r.isNil(err) && canBeNil(t) && !r.isNonNil(value):
+			r.m.warn(r.p, value.Pos(), "%s may be nil, which result.Ok rejects; rewrite this return by hand", r.text(value))
+		case r.isNil(err):
+			r.ed.insert(r.offset(value.Pos()), callPrefix("result", "Ok", ""))
+			r.ed.replace(r.offset(value.End()), r.offset(err.End()), ")")
+		case r.isZero(value):
+			r.ed.replace(r.offset(value.Pos()), r.offset(err.Pos()), callPrefix("result", "Err", r.typeString(t)))
+			r.ed.insert(r.offset(err.End()), ")")
+		default:
+			r.ed.insert(r.offset(value.Pos()), callPrefix("result", "From", ""))
+			r.ed.insert(r.offset(err.End()), ")")
+		}
+	}
+}
+
+// isNonNil reports whether expr is never nil: an address, a composite or function literal, or a call to make or new.
+func (r *fileRewriter) isNonNil(expr ast.Expr) bool {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.UnaryExpr:
+		return e.Op == token.AND
+	case *ast.CompositeLit, *ast.FuncLit:
+		return true
+	case *ast.CallExpr:
+		id, ok := ast.Unparen(e.Fun).(*ast.Ident)
+		return ok && (r.p.info.Uses[id] == types.Universe.Lookup("make") || r.p.info.Uses[id] == types.Universe.Lookup("new"))
+	}
+	return false
+}
+
+// canBeNil reports whether values of t can be nil, which result.Ok rejects.
+func canBeNil(t types.Type) bool {
+	switch u := t.Underlying().(type) {
+	case *types.Pointer, *types.Slice, *types.Map, *types.Chan, *types.Signature, *types.Interface:
+		return true
+	case *types.Basic:
+		return u.Kind() == types.UnsafePointer
+	}
+	return false
+}
+
+// isZero reports whether expr is a literal zero value: 0, "", false, nil or an empty composite literal.
+func (r *fileRewriter) isZero(expr ast.Expr) bool {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.BasicLit:
+		return e.Value == "0" || e.Value == `""` || e.Value == "``" || e.Value == "0.0"
+	case *ast.Ident:
+		return r.isNil(e) || (e.Name == "false" && r.p.info.Uses[e] == types.Universe.Lookup("false"))
+	case *ast.CompositeLit:
+		return len(e.Elts) == 0
+	}
+	return false
+}
+
+// rewriteCallSite appends .Get() to calls of migrated functions, so callers keep receiving (T, error).
+func (r *fileRewriter) rewriteCallSite(c *ast.CallExpr) {
+	if r.handled[c] {
+		return
+	}
+	if _, ok := r.targetCall(c); !ok {
+		return
+	}
+	r.ed.insert(r.offset(c.End()), ".Get()")
+}
+
+// warnDeferredCall reports go and defer statements calling a migrated function, whose results are discarded
+// and whose meaning would change by appending .Get().
+func (r *fileRewriter) warnDeferredCall(c *ast.CallExpr, keyword string) {
+	if _, ok := r.targetCall(c); ok {
+		r.handled[c] = true
+		r.m.warn(r.p, c.Pos(), "%s statement calls migrated %s; check that dropping the Result is intended", keyword, r.text(c.Fun))
+	}
+}
+
+// checkFuncValue warns about migrated functions used as values, whose type changes.
+func (r *fileRewriter) checkFuncValue(id *ast.Ident) {
+	fn, ok := r.p.info.Uses[id].(*types.Func)
+	if !ok || !r.m.funcKeys[funcKey(fn)] || r.isCalled(id) {
+		return
+	}
+	r.m.warn(r.p, id.Pos(), "%s is used as a function value; its type changes to return result.Result", id.Name)
+}
+
+// isCalled reports whether id is the function part of a call expression.
+func (r *fileRewriter) isCalled(id *ast.Ident) bool {
+	called := false
+	ast.Inspect(r.file, func(n ast.Node) bool {
+		c, ok := n.(*ast.CallExpr)
+		if !ok || called {
+			return !called
+		}
+		switch fun := ast.Unparen(c.Fun).(type) {
+		case *ast.Ident:
+			called = fun == id
+		case *ast.SelectorExpr:
+			called = fun.Sel == id
+		}
+		return !called
+	})
+	return called
+}
+
+// addImports adds the given import paths to src if missing, and formats the result.
+// New imports join the last group of non-standard imports, or start a new group after the standard ones.
+func addImports(src []byte, paths map[string]bool) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ImportsOnly)
+	if err != nil {
+		return nil, fmt.Errorf("rewritten code does not parse: %w", err)
+	}
+
+	var missing []string
+	for path := range paths {
+		if !hasImport(file, path) {
+			missing = append(missing, path)
+		}
+	}
+	sort.Strings(missing)
+
+	if len(missing) > 0 {
+		offset := func(pos token.Pos) int { return fset.Position(pos).Offset }
+		var lines strings.Builder
+		for _, path := range missing {
+			fmt.Fprintf(&lines, "\t%s\n", strconv.Quote(path))
+		}
+
+		var ed editor
+		var decl *ast.GenDecl
+		for _, d := range file.Decls {
+			if gd, ok := d.(*ast.GenDecl); ok && gd.Tok == token.IMPORT {
+				decl = gd
+			}
+		}
+		switch {
+		case decl == nil:
+			ed.insert(offset(file.Name.End()), "\n\nimport (\n"+lines.String()+")")
+		case !decl.Lparen.IsValid():
+			spec := decl.Specs[0].(*ast.ImportSpec)
+			ed.replace(offset(spec.Pos()), offset(spec.End()), "(\n\t"+string(src[offset(spec.Pos()):offset(spec.End())])+"\n\n"+lines.String()+")")
+		default:
+			var last *ast.ImportSpec
+			for _, spec := range decl.Specs {
+				if spec := spec.(*ast.ImportSpec); !isStdImport(spec) {
+					last = spec
+				}
+			}
+			if last != nil {
+				ed.insert(offset(last.End()), "\n"+strings.TrimSuffix(lines.String(), "\n"))
+			} else {
+				ed.insert(offset(decl.Rparen), "\n"+lines.String())
+			}
+		}
+		src, err = ed.apply(src)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fset = token.NewFileSet()
+	file, err = parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("rewritten code does not parse: %w", err)
+	}
+	ast.SortImports(fset, file)
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func hasImport(file *ast.File, path string) bool {
+	for _, spec := range file.Imports {
+		if p, _ := strconv.Unquote(spec.Path.Value); p == path {
+			return true
+		}
+	}
+	return false
+}
+
+// isStdImport reports whether the import path looks like a standard library package, which has no dot in its first element.
+func isStdImport(spec *ast.ImportSpec) bool {
+	path, _ := strconv.Unquote(spec.Path.Value)
+	first, _, _ := strings.Cut(path, "/")
+	return !strings.Contains(first, ".")
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files")
+
+var testDirs = []string{"testdata/models", "testdata/service"}
+
+var testOptions = Options{
+	Fields: []string{"User.Nickname", "User.Age"},
+	Funcs:  []string{"Repo.Find", "Load", "ParseID"},
+}
+
+// checkGolden compares got with the golden file, or rewrites it when -update is set.
+func checkGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("Output differs from %s; run go test -update to refresh it.\ngot:\n%s", path, got)
+	}
+}
+
+// checkCompiles vets the migrated packages with the rewritten files swapped in through an overlay,
+// so testdata stays untouched.
+func checkCompiles(t *testing.T, changes []Change) {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("skipping compilation in short mode")
+	}
+	tmp := t.TempDir()
+	replace := make(map[string]string)
+	for i, c := range changes {
+		rewritten := filepath.Join(tmp, strings.Repeat("x", i+1)+".go")
+		if err := os.WriteFile(rewritten, c.New, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		abs, err := filepath.Abs(c.Path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		replace[abs] = rewritten
+	}
+	overlay, err := json.Marshal(map[string]map[string]string{"Replace": replace})
+	if err != nil {
+		t.Fatal(err)
+	}
+	overlayPath := filepath.Join(tmp, "overlay.json")
+	if err := os.WriteFile(overlayPath, overlay, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command("go", append([]string{"vet", "-overlay=" + overlayPath}, "./testdata/models", "./testdata/service")...)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Errorf("Migrated code does not compile: %v\n%s", err, out)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	changes, warnings, err := Migrate(testDirs, testOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 2 {
+		t.Fatalf("Expected 2 changed files, got %d", len(changes))
+	}
+	for _, c := range changes {
+		checkGolden(t, filepath.Base(filepath.Dir(c.Path))+".golden", c.New)
+	}
+	checkCompiles(t, changes)
+
+	// The dry-run output for the dependent package.
+	service := changes[1]
+	checkGolden(t, "service.diff", []byte(unifiedDiff(filepath.ToSlash(service.Path), string(service.Old), string(service.New))))
+
+	want := []string{
+		"models.go:66:5: go statement calls migrated Load",
+		"models.go:67:12: Find is used as a function value",
+	}
+	if len(warnings) != len(want) {
+		t.Fatalf("Expected %d warnings, got %v", len(want), warnings)
+	}
+	for i, w := range warnings {
+		if !strings.Contains(w.String(), want[i]) {
+			t.Errorf("Expected warning %d to contain %q, got %q", i, want[i], w)
+		}
+	}
+}
+
+func TestMigrateNilableReturns(t *testing.T) {
+	opts := Options{Funcs: []string{"LoadAll", "Find", "Index", "Empty", "NameReader"}}
+	changes, warnings, err := Migrate([]string{"testdata/nilable"}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 {
+		t.Fatalf("Expected 1 changed file, got %d", len(changes))
+	}
+	checkGolden(t, "nilable.golden", changes[0].New)
+
+	want := []string{
+		"nilable.go:21:9: users may be nil, which result.Ok rejects; rewrite this return by hand",
+		"nilable.go:28:9: nil returned without an error has no Result equivalent; rewrite this return by hand",
+		"nilable.go:36:9: index may be nil, which result.Ok rejects; rewrite this return by hand",
+		"nilable.go:44:9: strings.NewReader(u.Name) may be nil, which result.Ok rejects; rewrite this return by hand",
+	}
+	if len(warnings) != len(want) {
+		t.Fatalf("Expected %d warnings, got %v", len(want), warnings)
+	}
+	for i, w := range warnings {
+		if !strings.HasSuffix(w.String(), want[i]) {
+			t.Errorf("Expected warning %d to end with %q, got %q", i, want[i], w)
+		}
+	}
+}
+
+func TestMigratePointerUse(t *testing.T) {
+	changes, warnings, err := Migrate([]string{"testdata/pointers"}, Options{Fields: []string{"Config.Timeout"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 {
+		t.Fatalf("Expected 1 changed file, got %d", len(changes))
+	}
+	if !strings.Contains(string(changes[0].New), "Timeout optional.Optional[time.Duration]") {
+		t.Errorf("Expected Timeout to be migrated, got:\n%s", changes[0].New)
+	}
+	if strings.Contains(string(changes[0].New), "Unwrap()") {
+		t.Errorf("Expected writes through the pointer to be left unchanged, got:\n%s", changes[0].New)
+	}
+
+	want := []string{
+		"pointers.go:14:9: c.Timeout is used as a pointer; rewrite this use by hand",
+		"pointers.go:18:13: c.Timeout is used as a pointer; rewrite this use by hand",
+		"pointers.go:22:2: *c.Timeout is written through the pointer; rewrite this use by hand",
+		"pointers.go:23:2: *c.Timeout is written through the pointer; rewrite this use by hand",
+		"pointers.go:24:3: *c.Timeout is written through the pointer; rewrite this use by hand",
+		"pointers.go:25:7: *c.Timeout is written through the pointer; rewrite this use by hand",
+	}
+	if len(warnings) != len(want) {
+		t.Fatalf("Expected %d warnings, got %v", len(want), warnings)
+	}
+	for i, w := range warnings {
+		if !strings.HasSuffix(w.String(), want[i]) {
+			t.Errorf("Expected warning %d to end with %q, got %q", i, want[i], w)
+		}
+	}
+}
+
+func TestMigrateOnlyFuncs(t *testing.T) {
+	changes, _, err := Migrate([]string{"testdata/models"}, Options{Funcs: []string{"ParseID"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 {
+		t.Fatalf("Expected 1 changed file, got %d", len(changes))
+	}
+	got := string(changes[0].New)
+	if !strings.Contains(got, "func ParseID(s string) result.Result[int] {") {
+		t.Errorf("Expected ParseID to return result.Result[int], got:\n%s", got)
+	}
+	if strings.Contains(got, "optional") {
+		t.Errorf("Expected no optional import when no fields are migrated, got:\n%s", got)
+	}
+}
+
+func TestMigrateErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+		want string
+	}{
+		{"field without type", Options{Fields: []string{"Nickname"}}, "must be written as Type.Field"},
+		{"unknown field", Options{Fields: []string{"User.Email"}}, "field User.Email not found"},
+		{"non-pointer field", Options{Fields: []string{"User.Name"}}, "field User.Name is not a pointer"},
+		{"unknown function", Options{Funcs: []string{"Save"}}, "function Save not found"},
+		{"wrong results", Options{Funcs: []string{"NewUser"}}, "NewUser does not return (T, error)"},
+		{"named results", Options{Funcs: []string{"SplitName"}}, "SplitName has named results"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := Migrate([]string{"testdata/models"}, tt.opts)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestAddImports(t *testing.T) {
+	paths := map[string]bool{optionalPath: true}
+	tests := []struct {
+		name, src, want string
+	}{
+		{
+			name: "no imports",
+			src:  "package p\n\nvar x = 1\n",
+			want: "package p\n\nimport (\n\t\"github.com/azat-dev/go-utils/optional\"\n)\n\nvar x = 1\n",
+		},
+		{
+			name: "single import",
+			src:  "package p\n\nimport \"fmt\"\n",
+			want: "package p\n\nimport (\n\t\"fmt\"\n\n\t\"github.com/azat-dev/go-utils/optional\"\n)\n",
+		},
+		{
+			name: "joins third-party group",
+			src:  "package p\n\nimport (\n\t\"fmt\"\n\n\t\"github.com/azat-dev/go-utils/result\"\n)\n",
+			want: "package p\n\nimport (\n\t\"fmt\"\n\n\t\"github.com/azat-dev/go-utils/optional\"\n\t\"github.com/azat-dev/go-utils/result\"\n)\n",
+		},
+		{
+			name: "already imported",
+			src:  "package p\n\nimport \"github.com/azat-dev/go-utils/optional\"\n",
+			want: "package p\n\nimport \"github.com/azat-dev/go-utils/optional\"\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := addImports([]byte(tt.src), paths)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Expected:\n%s\ngot:\n%s", tt.want, got)
+			}
+		})
+	}
+}
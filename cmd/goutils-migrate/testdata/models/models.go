@@ -0,0 +1,76 @@
+package models
+
+import (
+	"errors"
+	"strconv"
+)
+
+var ErrNotFound = errors.New("not found")
+
+type User struct {
+	ID       int
+	Name     string
+	Nickname *string
+	Age      *int
+}
+
+func NewUser(id int, name string, nickname string) User {
+	u := User{ID: id, Name: name, Nickname: &nickname, Age: nil}
+	return u
+}
+
+func (u *User) DisplayName() string {
+	if u.Nickname != nil {
+		return *u.Nickname
+	}
+	return u.Name
+}
+
+func (u *User) ClearAge() {
+	u.Age = nil
+}
+
+func (u *User) SetAge(age *int) {
+	u.Age = age
+}
+
+func (u *User) HasAge() bool {
+	return nil != u.Age
+}
+
+type Repo struct {
+	users map[int]User
+}
+
+func (r *Repo) Find(id int) (User, error) {
+	u, ok := r.users[id]
+	if !ok {
+		return User{}, ErrNotFound
+	}
+	return u, nil
+}
+
+func ParseID(s string) (int, error) {
+	return strconv.Atoi(s)
+}
+
+func Load(r *Repo, s string) (User, error) {
+	id, err := ParseID(s)
+	if err != nil {
+		return User{}, err
+	}
+	return r.Find(id)
+}
+
+func Background(r *Repo) {
+	go Load(r, "1")
+	find := r.Find
+	_ = find
+}
+
+func SplitName(s string) (first string, err error) {
+	if s == "" {
+		return "", errors.New("empty name")
+	}
+	return s, nil
+}
@@ -0,0 +1,45 @@
+package nilable
+
+import (
+	"errors"
+	"io"
+	"strings"
+)
+
+type User struct {
+	Name string
+}
+
+func LoadAll(names []string) ([]User, error) {
+	var users []User
+	for _, name := range names {
+		if name == "" {
+			return nil, errors.New("empty name")
+		}
+		users = append(users, User{Name: name})
+	}
+	return users, nil
+}
+
+func Find(users map[string]User, name string) (*User, error) {
+	if u, ok := users[name]; ok {
+		return &u, nil
+	}
+	return nil, nil
+}
+
+func Index(users []User) (map[string]User, error) {
+	index := make(map[string]User, len(users))
+	for _, u := range users {
+		index[u.Name] = u
+	}
+	return index, nil
+}
+
+func Empty() (map[string]User, error) {
+	return map[string]User{}, nil
+}
+
+func NameReader(u User) (io.Reader, error) {
+	return strings.NewReader(u.Name), nil
+}
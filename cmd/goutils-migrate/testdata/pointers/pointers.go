@@ -0,0 +1,26 @@
+package pointers
+
+import "time"
+
+type Config struct {
+	Timeout *time.Duration
+}
+
+func setDefault(d *time.Duration) {
+	*d = time.Second
+}
+
+func timeoutRef(c *Config) *time.Duration {
+	return c.Timeout
+}
+
+func fillDefaults(c *Config) {
+	setDefault(c.Timeout)
+}
+
+func reset(c *Config) {
+	*c.Timeout = 0
+	*c.Timeout += time.Second
+	(*c.Timeout)++
+	_ = &*c.Timeout
+}
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/azat-dev/go-utils/cmd/goutils-migrate/testdata/models"
+)
+
+func Describe(r *models.Repo, id string) (string, error) {
+	u, err := models.Load(r, id)
+	if err != nil {
+		return "", fmt.Errorf("describe %s: %w", id, err)
+	}
+	if u.Age == nil {
+		return u.Name, nil
+	}
+	return fmt.Sprintf("%s (%d)", u.Name, *u.Age), nil
+}
@@ -0,0 +1,326 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	optionalPath = "github.com/azat-dev/go-utils/optional"
+	resultPath   = "github.com/azat-dev/go-utils/result"
+	tagKey       = "optgen"
+	generatedBy  = "// Code generated by optgen; DO NOT EDIT."
+)
+
+// field describes one struct field as seen by the generated code.
+type field struct {
+	Name     string
+	Method   string
+	Type     string
+	Optional bool
+	Required bool
+}
+
+// structInfo describes one struct the generator writes code for.
+type structInfo struct {
+	Name    string
+	Builder string
+	New     string
+	Fields  []field
+}
+
+// Generate type-checks the package in dir and returns the source of the generated file.
+// If typeNames is empty, code is generated for every struct with at least one optional.Optional field.
+func Generate(dir string, typeNames []string) ([]byte, error) {
+	pkg, err := loadPackage(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	names := typeNames
+	if len(names) == 0 {
+		names = pkg.Scope().Names()
+	}
+
+	imports := map[string]string{optionalPath: "optional", resultPath: "result"}
+	qualifier := func(p *types.Package) string {
+		if p.Path() == pkg.Path() {
+			return ""
+		}
+		imports[p.Path()] = p.Name()
+		return p.Name()
+	}
+
+	var infos []structInfo
+	for _, name := range names {
+		obj, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+		if !ok {
+			if len(typeNames) > 0 {
+				return nil, fmt.Errorf("optgen: type %s not found in %s", name, dir)
+			}
+			continue
+		}
+		info, ok, err := inspectStruct(obj, qualifier)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			if len(typeNames) > 0 {
+				return nil, fmt.Errorf("optgen: %s is not a non-generic struct type", name)
+			}
+			continue
+		}
+		if len(typeNames) == 0 && !slices.ContainsFunc(info.Fields, func(f field) bool { return f.Optional }) {
+			continue
+		}
+		infos = append(infos, info)
+	}
+	if len(infos) == 0 {
+		return nil, fmt.Errorf("optgen: no structs with optional.Optional fields in %s", dir)
+	}
+
+	return render(pkg.Name(), imports, infos)
+}
+
+// loadPackage parses and type-checks the Go files of the package in dir, leaving out files generated by optgen.
+func loadPackage(dir string) (*types.Package, error) {
+	buildPkg, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, fmt.Errorf("optgen: %w", err)
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("optgen: %w", err)
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range buildPkg.GoFiles {
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, fmt.Errorf("optgen: %w", err)
+		}
+		if isOptgenOutput(file) {
+			continue
+		}
+		files = append(files, file)
+	}
+
+	config := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		// Code in the package may refer to builders from the generated file that was left out,
+		// so type errors are ignored: struct definitions are still fully resolved.
+		Error: func(error) {},
+	}
+	pkg, _ := config.Check(absDir, fset, files, nil)
+	if pkg == nil {
+		return nil, fmt.Errorf("optgen: failed to type-check %s", dir)
+	}
+	return pkg, nil
+}
+
+func isOptgenOutput(file *ast.File) bool {
+	for _, group := range file.Comments {
+		if group.Pos() > file.Package {
+			break
+		}
+		for _, c := range group.List {
+			if c.Text == generatedBy {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// inspectStruct collects the fields of a named struct type. It reports false for other types.
+func inspectStruct(obj *types.TypeName, qualifier types.Qualifier) (structInfo, bool, error) {
+	named, ok := obj.Type().(*types.Named)
+	if !ok || named.TypeParams().Len() > 0 {
+		return structInfo{}, false, nil
+	}
+	st, ok := named.Underlying().(*types.Struct)
+	if !ok {
+		return structInfo{}, false, nil
+	}
+
+	info := structInfo{
+		Name:    obj.Name(),
+		Builder: obj.Name() + "Builder",
+		New:     "New" + exportName(obj.Name()) + "Builder",
+		Fields:  nil,
+	}
+	if !obj.Exported() {
+		info.New = "new" + exportName(obj.Name()) + "Builder"
+	}
+
+	for i := range st.NumFields() {
+		v := st.Field(i)
+		tag := reflect.StructTag(st.Tag(i)).Get(tagKey)
+		if tag == "-" || v.Name() == "_" {
+			continue
+		}
+		if tag != "" && tag != "optional" {
+			return structInfo{}, false, fmt.Errorf("optgen: %s.%s: unknown tag value %q", obj.Name(), v.Name(), tag)
+		}
+		f := field{
+			Name:     v.Name(),
+			Method:   exportName(v.Name()),
+			Type:     types.TypeString(v.Type(), qualifier),
+			Optional: false,
+			Required: tag != "optional",
+		}
+		if inner, ok := optionalElem(v.Type()); ok {
+			f.Type = types.TypeString(inner, qualifier)
+			f.Optional = true
+			f.Required = false
+		}
+		info.Fields = append(info.Fields, f)
+	}
+	return info, true, nil
+}
+
+// optionalElem returns T if t is optional.Optional[T].
+func optionalElem(t types.Type) (types.Type, bool) {
+	named, ok := t.(*types.Named)
+	if !ok {
+		return nil, false
+	}
+	obj := named.Obj()
+	if obj.Pkg() == nil || obj.Pkg().Path() != optionalPath || obj.Name() != "Optional" {
+		return nil, false
+	}
+	return named.TypeArgs().At(0), true
+}
+
+func exportName(name string) string {
+	runes := []rune(name)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+func render(pkgName string, imports map[string]string, infos []structInfo) ([]byte, error) {
+	hasOptional := slices.ContainsFunc(infos, func(info structInfo) bool {
+		return slices.ContainsFunc(info.Fields, func(f field) bool { return f.Optional })
+	})
+	hasRequired := slices.ContainsFunc(infos, func(info structInfo) bool {
+		return slices.ContainsFunc(info.Fields, func(f field) bool { return f.Required })
+	})
+	if !hasOptional {
+		delete(imports, optionalPath)
+	}
+
+	std := make([]string, 0, len(imports))
+	other := make([]string, 0, len(imports))
+	for path := range imports {
+		if strings.Contains(strings.SplitN(path, "/", 2)[0], ".") {
+			other = append(other, path)
+		} else {
+			std = append(std, path)
+		}
+	}
+	if hasRequired {
+		std = append(std, "strings")
+	}
+	sort.Strings(std)
+	sort.Strings(other)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s\n\npackage %s\n\nimport (\n", generatedBy, pkgName)
+	for i, group := range [][]string{std, other} {
+		if i > 0 && len(std) > 0 && len(other) > 0 {
+			buf.WriteString("\n")
+		}
+		for _, path := range group {
+			if name, ok := imports[path]; ok && filepath.Base(path) != name {
+				fmt.Fprintf(&buf, "\t%s %q\n", name, path)
+			} else {
+				fmt.Fprintf(&buf, "\t%q\n", path)
+			}
+		}
+	}
+	buf.WriteString(")\n")
+
+	for _, info := range infos {
+		renderStruct(&buf, info)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("optgen: formatting generated code: %w", err)
+	}
+	return src, nil
+}
+
+func renderStruct(buf *bytes.Buffer, info structInfo) {
+	b := info.Builder
+	fmt.Fprintf(buf, "\n// %s builds a %s field by field.\n", b, info.Name)
+	fmt.Fprintf(buf, "type %s struct {\n\tvalue %s\n", b, info.Name)
+	for _, f := range info.Fields {
+		if f.Required {
+			fmt.Fprintf(buf, "\thas%s bool\n", f.Method)
+		}
+	}
+	buf.WriteString("}\n")
+
+	fmt.Fprintf(buf, "\n// %s creates an empty %s. Optional fields start as None.\n", info.New, b)
+	fmt.Fprintf(buf, "func %s() *%s {\n\treturn &%s{}\n}\n", info.New, b, b)
+
+	for _, f := range info.Fields {
+		switch {
+		case f.Optional:
+			fmt.Fprintf(buf, "\n// With%s sets %s to Some(v).\n", f.Method, f.Name)
+			fmt.Fprintf(buf, "func (b *%s) With%s(v %s) *%s {\n\tb.value.%s = optional.Some(v)\n\treturn b\n}\n",
+				b, f.Method, f.Type, b, f.Name)
+			fmt.Fprintf(buf, "\n// Clear%s sets %s to None.\n", f.Method, f.Name)
+			fmt.Fprintf(buf, "func (b *%s) Clear%s() *%s {\n\tb.value.%s = optional.None[%s]()\n\treturn b\n}\n",
+				b, f.Method, b, f.Name, f.Type)
+		case f.Required:
+			fmt.Fprintf(buf, "\n// With%s sets the required field %s.\n", f.Method, f.Name)
+			fmt.Fprintf(buf, "func (b *%s) With%s(v %s) *%s {\n\tb.value.%s = v\n\tb.has%s = true\n\treturn b\n}\n",
+				b, f.Method, f.Type, b, f.Name, f.Method)
+		default:
+			fmt.Fprintf(buf, "\n// With%s sets %s.\n", f.Method, f.Name)
+			fmt.Fprintf(buf, "func (b *%s) With%s(v %s) *%s {\n\tb.value.%s = v\n\treturn b\n}\n",
+				b, f.Method, f.Type, b, f.Name)
+		}
+	}
+
+	fmt.Fprintf(buf, "\n// Build returns the %s, or an Err listing the required fields that were never set.\n", info.Name)
+	fmt.Fprintf(buf, "func (b *%s) Build() result.Result[%s] {\n", b, info.Name)
+	if slices.ContainsFunc(info.Fields, func(f field) bool { return f.Required }) {
+		buf.WriteString("\tvar missing []string\n")
+		for _, f := range info.Fields {
+			if f.Required {
+				fmt.Fprintf(buf, "\tif !b.has%s {\n\t\tmissing = append(missing, %q)\n\t}\n", f.Method, f.Name)
+			}
+		}
+		buf.WriteString("\tif len(missing) > 0 {\n")
+		fmt.Fprintf(buf, "\t\treturn result.ErrorF[%s](\"%s: missing required fields: %%s\", strings.Join(missing, \", \"))\n",
+			info.Name, info.Name)
+		buf.WriteString("\t}\n")
+	}
+	buf.WriteString("\treturn result.Ok(b.value)\n}\n")
+
+	for _, f := range info.Fields {
+		if !f.Optional {
+			continue
+		}
+		fmt.Fprintf(buf, "\n// Get%s returns the value of %s and true if it is set, or the zero value and false.\n",
+			f.Method, f.Name)
+		fmt.Fprintf(buf, "func (s %s) Get%s() (%s, bool) {\n\treturn s.%s.Get()\n}\n", info.Name, f.Method, f.Type, f.Name)
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files")
+
+// checkGolden compares got with the golden file, or rewrites it when -update is set.
+func checkGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("Generated code differs from %s; run go test -update to refresh it.\ngot:\n%s", path, got)
+	}
+}
+
+// checkCompiles builds dir with the generated file added through an overlay, so testdata stays untouched.
+func checkCompiles(t *testing.T, dir string, src []byte) {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("skipping compilation in short mode")
+	}
+	tmp := t.TempDir()
+	generated := filepath.Join(tmp, "generated.go")
+	if err := os.WriteFile(generated, src, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	overlay, err := json.Marshal(map[string]map[string]string{
+		"Replace": {filepath.Join(absDir, "zz_optgen.go"): generated},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	overlayPath := filepath.Join(tmp, "overlay.json")
+	if err := os.WriteFile(overlayPath, overlay, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command("go", "vet", "-overlay="+overlayPath, "./"+filepath.ToSlash(dir))
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Errorf("Generated code does not compile: %v\n%s", err, out)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	t.Run("all structs with Optional fields", func(t *testing.T) {
+		src, err := Generate("testdata/basic", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkGolden(t, "basic.golden", src)
+		checkCompiles(t, "testdata/basic", src)
+	})
+
+	t.Run("selected types", func(t *testing.T) {
+		src, err := Generate("testdata/basic", []string{"Plain"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkGolden(t, "plain.golden", src)
+	})
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		dir   string
+		types []string
+		msg   string
+	}{
+		{"unknown type", "testdata/basic", []string{"Missing"}, "type Missing not found"},
+		{"not a struct", "testdata/invalid", []string{"ID"}, "ID is not a non-generic struct type"},
+		{"unknown tag", "testdata/invalid", []string{"BadTag"}, `unknown tag value "maybe"`},
+		{"nothing to generate", "testdata/plain", nil, "no structs with optional.Optional fields"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Generate(tt.dir, tt.types)
+			if err == nil || !strings.Contains(err.Error(), tt.msg) {
+				t.Errorf("Expected error containing '%s', got %v", tt.msg, err)
+			}
+		})
+	}
+}
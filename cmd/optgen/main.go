@@ -0,0 +1,73 @@
+// Command optgen generates fluent builders and getters for structs with optional.Optional fields.
+//
+// For every selected struct T it writes:
+//
+//   - TBuilder with NewTBuilder(), a WithX(v) method per field (Optional fields are set to Some(v))
+//     and a ClearX() method per Optional field (set to None);
+//   - TBuilder.Build() returning result.Result[T], which fails if a required field was never set;
+//   - a GetX() (value, bool) getter on T per Optional field.
+//
+// Fields that are not optional.Optional are required unless tagged `optgen:"optional"`.
+// Fields tagged `optgen:"-"` are left out.
+//
+// Usage, typically from a go:generate directive in the package:
+//
+//	//go:generate go run github.com/azat-dev/go-utils/cmd/optgen -type=User,Order
+//
+// Without -type, code is generated for every struct with at least one optional.Optional field.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	typeNames := flag.String("type", "", "comma-separated list of struct names; default is every struct with Optional fields")
+	output := flag.String("output", "", "output file name; default is <package>_optgen.go in the package directory")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: optgen [-type=A,B] [-output=file] [directory]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+
+	var names []string
+	if *typeNames != "" {
+		names = strings.Split(*typeNames, ",")
+	}
+
+	src, err := Generate(dir, names)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	path := *output
+	if path == "" {
+		path = filepath.Join(dir, packageFileName(dir)+"_optgen.go")
+	}
+	if err := os.WriteFile(path, src, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// packageFileName returns the package name set by go generate, or the directory name.
+func packageFileName(dir string) string {
+	if name := os.Getenv("GOPACKAGE"); name != "" {
+		return name
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "generated"
+	}
+	return strings.ToLower(filepath.Base(abs))
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/azat-dev/go-utils/optional"
+)
+
+type User struct {
+	ID        int
+	Email     string
+	Nickname  optional.Optional[string]
+	BirthDate optional.Optional[time.Time]
+	Tags      []string `optgen:"optional"`
+	internal  string   `optgen:"-"`
+}
+
+type settings struct {
+	theme optional.Optional[string]
+}
+
+// Plain has no Optional fields and is skipped unless selected with -type.
+type Plain struct {
+	Name string
+}
+
+func useBuilder() {
+	_ = NewUserBuilder().WithID(1).Build()
+}
@@ -0,0 +1,7 @@
+package invalid
+
+type ID int
+
+type BadTag struct {
+	Name string `optgen:"maybe"`
+}
@@ -0,0 +1,5 @@
+package plain
+
+type Point struct {
+	X, Y int
+}
@@ -0,0 +1,373 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+const (
+	optionalPath = "github.com/azat-dev/go-utils/optional"
+	resultPath   = "github.com/azat-dev/go-utils/result"
+
+	ignoreDirective = "//resultcheck:ignore"
+)
+
+// Check names, used in messages and in suppression comments.
+const (
+	checkUnwrap  = "unwrap"
+	checkDropped = "dropped"
+	checkNilSome = "nilsome"
+	checkCompare = "compare"
+)
+
+// Diagnostic is a single problem found by the checker.
+type Diagnostic struct {
+	Pos     token.Position
+	Check   string
+	Message string
+}
+
+// String formats the diagnostic as "file:line:col: message (check)".
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s: %s (%s)", d.Pos, d.Message, d.Check)
+}
+
+// checker walks the files of one type-checked package.
+type checker struct {
+	fset        *token.FileSet
+	info        *types.Info
+	diagnostics []Diagnostic
+	// ignored maps file name and line to the checks suppressed on that line; an empty set suppresses all.
+	ignored map[string]map[int][]string
+}
+
+// Check reports unsafe Unwrap calls, dropped Results, Some/Ok called with nil and Optional comparisons.
+func Check(fset *token.FileSet, files []*ast.File, info *types.Info) []Diagnostic {
+	c := &checker{
+		fset:        fset,
+		info:        info,
+		diagnostics: nil,
+		ignored:     make(map[string]map[int][]string),
+	}
+	for _, file := range files {
+		c.collectIgnores(file)
+	}
+	for _, file := range files {
+		c.checkFile(file)
+	}
+	return c.diagnostics
+}
+
+// collectIgnores records //resultcheck:ignore comments. A comment trailing code applies to its own line,
+// and a comment standing on its own line applies to the next one.
+func (c *checker) collectIgnores(file *ast.File) {
+	// codeStart maps each line holding code to the position of its first node boundary.
+	codeStart := make(map[int]token.Pos)
+	ast.Inspect(file, func(n ast.Node) bool {
+		if n == nil {
+			return false
+		}
+		if _, ok := n.(*ast.CommentGroup); ok {
+			return false
+		}
+		for _, p := range []token.Pos{n.Pos(), n.End()} {
+			line := c.fset.Position(p).Line
+			if start, ok := codeStart[line]; !ok || p < start {
+				codeStart[line] = p
+			}
+		}
+		return true
+	})
+	for _, group := range file.Comments {
+		for _, comment := range group.List {
+			rest, ok := strings.CutPrefix(comment.Text, ignoreDirective)
+			if !ok || (rest != "" && rest[0] != ' ') {
+				continue
+			}
+			checks := strings.Fields(rest)
+			pos := c.fset.Position(comment.Pos())
+			lines := c.ignored[pos.Filename]
+			if lines == nil {
+				lines = make(map[int][]string)
+				c.ignored[pos.Filename] = lines
+			}
+			if start, ok := codeStart[pos.Line]; ok && start < comment.Pos() {
+				lines[pos.Line] = checks
+			} else {
+				lines[pos.Line+1] = checks
+			}
+		}
+	}
+}
+
+func (c *checker) isIgnored(pos token.Position, check string) bool {
+	checks, ok := c.ignored[pos.Filename][pos.Line]
+	if !ok {
+		return false
+	}
+	if len(checks) == 0 {
+		return true
+	}
+	for _, name := range checks {
+		if name == check {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *checker) report(node ast.Node, check, format string, args ...any) {
+	pos := c.fset.Position(node.Pos())
+	if c.isIgnored(pos, check) {
+		return
+	}
+	c.diagnostics = append(c.diagnostics, Diagnostic{Pos: pos, Check: check, Message: fmt.Sprintf(format, args...)})
+}
+
+func (c *checker) checkFile(file *ast.File) {
+	var stack []ast.Node
+	ast.Inspect(file, func(n ast.Node) bool {
+		if n == nil {
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		switch n := n.(type) {
+		case *ast.ExprStmt:
+			c.checkDropped(n, n.X)
+		case *ast.GoStmt:
+			c.checkDropped(n, n.Call)
+		case *ast.DeferStmt:
+			c.checkDropped(n, n.Call)
+		case *ast.CallExpr:
+			c.checkNilSome(n)
+			c.checkUnwrap(n, stack)
+		case *ast.BinaryExpr:
+			c.checkCompare(n)
+		}
+		stack = append(stack, n)
+		return true
+	})
+}
+
+// checkDropped reports call statements, including go and defer statements, whose Result value is never read.
+// Inspect and InspectErr are skipped, since calling them for their side effect is their purpose.
+// Assigning to the blank identifier, as in "_ = f()", is an explicit discard and is not reported.
+func (c *checker) checkDropped(stmt ast.Stmt, expr ast.Expr) {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok || !isNamed(c.info.TypeOf(call), resultPath, "Result") {
+		return
+	}
+	if sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr); ok {
+		if name := sel.Sel.Name; name == "Inspect" || name == "InspectErr" {
+			return
+		}
+	}
+	c.report(stmt, checkDropped, "result of %s is not used", types.ExprString(call.Fun))
+}
+
+// checkNilSome reports optional.Some(nil) and result.Ok(nil), which always panic.
+func (c *checker) checkNilSome(call *ast.CallExpr) {
+	if len(call.Args) != 1 {
+		return
+	}
+	fn := calledFunc(c.info, call)
+	if fn == nil || fn.Pkg() == nil {
+		return
+	}
+	isSome := fn.Pkg().Path() == optionalPath && fn.Name() == "Some"
+	isOk := fn.Pkg().Path() == resultPath && fn.Name() == "Ok"
+	if !isSome && !isOk {
+		return
+	}
+	if id, ok := ast.Unparen(call.Args[0]).(*ast.Ident); ok && c.info.Uses[id] == types.Universe.Lookup("nil") {
+		c.report(call, checkNilSome, "%s called with nil always panics", fn.Name())
+	}
+}
+
+// checkCompare reports == and != between Optional values, which compare the hidden fields
+// instead of using optional.Equal.
+func (c *checker) checkCompare(expr *ast.BinaryExpr) {
+	if expr.Op != token.EQL && expr.Op != token.NEQ {
+		return
+	}
+	if isNamed(c.info.TypeOf(expr.X), optionalPath, "Optional") || isNamed(c.info.TypeOf(expr.Y), optionalPath, "Optional") {
+		c.report(expr, checkCompare, "Optional compared with %s; use optional.Equal", expr.Op)
+	}
+}
+
+// guard describes the checks that make a panicking accessor safe.
+type guard struct {
+	// safe is the method that must have returned true, for example IsOk before Unwrap.
+	safe string
+	// unsafe is the method that must have returned false, for example IsErr before Unwrap.
+	unsafe string
+}
+
+// checkUnwrap reports Unwrap, UnwrapErr and MustGet calls that are not dominated by a check of the same receiver.
+func (c *checker) checkUnwrap(call *ast.CallExpr, stack []ast.Node) {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok || len(call.Args) != 0 {
+		return
+	}
+	recvType := c.info.TypeOf(sel.X)
+	var g guard
+	switch {
+	case isNamed(recvType, resultPath, "Result") && (sel.Sel.Name == "Unwrap" || sel.Sel.Name == "MustGet"):
+		g = guard{safe: "IsOk", unsafe: "IsErr"}
+	case isNamed(recvType, resultPath, "Result") && sel.Sel.Name == "UnwrapErr":
+		g = guard{safe: "IsErr", unsafe: "IsOk"}
+	case isNamed(recvType, optionalPath, "Optional") && sel.Sel.Name == "Unwrap":
+		g = guard{safe: "IsSome", unsafe: "IsNone"}
+	default:
+		return
+	}
+
+	recv := types.ExprString(ast.Unparen(sel.X))
+	if c.isGuarded(call, stack, recv, g) || inMustFunc(stack) {
+		return
+	}
+	c.report(call, checkUnwrap, "%s.%s() may panic: no dominating %s() check", recv, sel.Sel.Name, g.safe)
+}
+
+// inMustFunc reports whether the innermost enclosing function is a declared function or method named Must...,
+// whose documented purpose, like regexp.MustCompile, is to panic when the operation fails.
+func inMustFunc(stack []ast.Node) bool {
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch fn := stack[i].(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.FuncDecl:
+			return strings.HasPrefix(fn.Name.Name, "Must")
+		}
+	}
+	return false
+}
+
+// isGuarded walks the ancestors of node looking for an if statement whose condition proves the guard,
+// or an earlier statement in an enclosing block that returns when the guard fails.
+func (c *checker) isGuarded(node ast.Node, stack []ast.Node, recv string, g guard) bool {
+	child := node
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch parent := stack[i].(type) {
+		case *ast.IfStmt:
+			if child == parent.Body && c.implies(parent.Cond, recv, g, true) {
+				return true
+			}
+			if child == parent.Else && c.implies(parent.Cond, recv, g, false) {
+				return true
+			}
+		case *ast.BinaryExpr:
+			// In "a && b", b only runs if a is true; in "a || b", only if a is false.
+			if child == parent.Y && parent.Op == token.LAND && c.implies(parent.X, recv, g, true) {
+				return true
+			}
+			if child == parent.Y && parent.Op == token.LOR && c.implies(parent.X, recv, g, false) {
+				return true
+			}
+		case *ast.CaseClause:
+			// In a tagless switch, a case body only runs if its single expression is true.
+			if i >= 2 && len(parent.List) == 1 && child != parent.List[0] {
+				if sw, ok := stack[i-2].(*ast.SwitchStmt); ok && sw.Tag == nil && c.implies(parent.List[0], recv, g, true) {
+					return true
+				}
+			}
+		case *ast.BlockStmt:
+			for _, stmt := range parent.List {
+				if stmt == child {
+					break
+				}
+				if ifStmt, ok := stmt.(*ast.IfStmt); ok && ifStmt.Else == nil &&
+					c.implies(ifStmt.Cond, recv, g, false) && terminates(ifStmt.Body) {
+					return true
+				}
+			}
+		case *ast.FuncLit, *ast.FuncDecl:
+			return false
+		}
+		child = stack[i]
+	}
+	return false
+}
+
+// implies reports whether cond evaluating to want proves that the guard holds for recv.
+func (c *checker) implies(cond ast.Expr, recv string, g guard, want bool) bool {
+	switch e := ast.Unparen(cond).(type) {
+	case *ast.UnaryExpr:
+		if e.Op == token.NOT {
+			return c.implies(e.X, recv, g, !want)
+		}
+	case *ast.BinaryExpr:
+		// "a && b" true means both are true; "a || b" false means both are false.
+		if (e.Op == token.LAND && want) || (e.Op == token.LOR && !want) {
+			return c.implies(e.X, recv, g, want) || c.implies(e.Y, recv, g, want)
+		}
+	case *ast.CallExpr:
+		sel, ok := ast.Unparen(e.Fun).(*ast.SelectorExpr)
+		if !ok || len(e.Args) != 0 || types.ExprString(ast.Unparen(sel.X)) != recv {
+			return false
+		}
+		return (want && sel.Sel.Name == g.safe) || (!want && sel.Sel.Name == g.unsafe)
+	}
+	return false
+}
+
+// terminates reports whether a block always leaves the enclosing flow:
+// it ends with return, break, continue, goto, panic, os.Exit, or a Fatal, Panic or Skip style call.
+func terminates(block *ast.BlockStmt) bool {
+	if len(block.List) == 0 {
+		return false
+	}
+	switch last := block.List[len(block.List)-1].(type) {
+	case *ast.ReturnStmt, *ast.BranchStmt:
+		return true
+	case *ast.ExprStmt:
+		call, ok := last.X.(*ast.CallExpr)
+		if !ok {
+			return false
+		}
+		switch fun := ast.Unparen(call.Fun).(type) {
+		case *ast.Ident:
+			return fun.Name == "panic"
+		case *ast.SelectorExpr:
+			name := fun.Sel.Name
+			return name == "Exit" || name == "FailNow" ||
+				strings.HasPrefix(name, "Fatal") || strings.HasPrefix(name, "Panic") || strings.HasPrefix(name, "Skip")
+		}
+	}
+	return false
+}
+
+// calledFunc returns the function or method called by call, or nil for conversions and function values.
+func calledFunc(info *types.Info, call *ast.CallExpr) *types.Func {
+	fun := ast.Unparen(call.Fun)
+	if index, ok := fun.(*ast.IndexExpr); ok {
+		fun = index.X
+	}
+	if index, ok := fun.(*ast.IndexListExpr); ok {
+		fun = index.X
+	}
+	var id *ast.Ident
+	switch f := fun.(type) {
+	case *ast.Ident:
+		id = f
+	case *ast.SelectorExpr:
+		id = f.Sel
+	default:
+		return nil
+	}
+	fn, _ := info.Uses[id].(*types.Func)
+	return fn
+}
+
+// isNamed reports whether t is the generic type pkgPath.name, instantiated with any type arguments.
+func isNamed(t types.Type, pkgPath, name string) bool {
+	named, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == pkgPath && obj.Name() == name
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+var wantPattern = regexp.MustCompile("// want `([^`]*)`")
+
+// expectations reads the `// want` comments of the Go files in dir, keyed by "file:line".
+func expectations(t *testing.T, dir string) map[string][]*regexp.Regexp {
+	t.Helper()
+	wants := make(map[string][]*regexp.Regexp)
+	matches, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fset := token.NewFileSet()
+	for _, path := range matches {
+		file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, group := range file.Comments {
+			for _, comment := range group.List {
+				for _, m := range wantPattern.FindAllStringSubmatch(comment.Text, -1) {
+					pos := fset.Position(comment.Pos())
+					key := fmt.Sprintf("%s:%d", filepath.Base(pos.Filename), pos.Line)
+					wants[key] = append(wants[key], regexp.MustCompile(m[1]))
+				}
+			}
+		}
+	}
+	return wants
+}
+
+// runTestdata checks that the diagnostics for dir match its `// want` comments exactly.
+func runTestdata(t *testing.T, dir string, tests bool) {
+	t.Helper()
+	diagnostics, err := CheckDir(dir, tests)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wants := expectations(t, dir)
+	for _, d := range diagnostics {
+		key := fmt.Sprintf("%s:%d", filepath.Base(d.Pos.Filename), d.Pos.Line)
+		matched := false
+		for i, re := range wants[key] {
+			if re.MatchString(d.Message) {
+				wants[key] = append(wants[key][:i], wants[key][i+1:]...)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			t.Errorf("Unexpected diagnostic: %s", d)
+		}
+	}
+	for key, res := range wants {
+		for _, re := range res {
+			t.Errorf("%s: expected diagnostic matching %q", key, re)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	runTestdata(t, "testdata/basic", false)
+}
+
+func TestCheckClean(t *testing.T) {
+	runTestdata(t, "testdata/clean", false)
+}
+
+func TestCheckTests(t *testing.T) {
+	diagnostics, err := CheckDir("testdata/clean", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diagnostics) != 1 || !strings.HasSuffix(diagnostics[0].Pos.Filename, "clean_test.go") {
+		t.Errorf("Expected one diagnostic in clean_test.go, got %v", diagnostics)
+	}
+	expected := "clean_test.go:6:6: parse(\"x\").MustGet() may panic: no dominating IsOk() check (unwrap)"
+	if len(diagnostics) == 1 && !strings.HasSuffix(diagnostics[0].String(), expected) {
+		t.Errorf("Expected '%s', got '%s'", expected, diagnostics[0])
+	}
+}
+
+func TestExpand(t *testing.T) {
+	dirs, err := expand([]string{"testdata/...", "."})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{filepath.Join("testdata", "basic"), filepath.Join("testdata", "clean"), "."}
+	if !slices.Equal(dirs, expected) {
+		t.Errorf("Expected %v, got %v", expected, dirs)
+	}
+}
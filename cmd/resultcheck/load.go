@@ -0,0 +1,71 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+)
+
+// CheckDir type-checks the package in dir and runs Check on it.
+// With tests set, the package's _test.go files are checked too, including an external _test package.
+func CheckDir(dir string, tests bool) ([]Diagnostic, error) {
+	buildPkg, err := build.ImportDir(dir, 0)
+	if err != nil {
+		var noGo *build.NoGoError
+		if errors.As(err, &noGo) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("resultcheck: %w", err)
+	}
+
+	fset := token.NewFileSet()
+	imp := importer.ForCompiler(fset, "source", nil)
+
+	names := buildPkg.GoFiles
+	if tests {
+		names = append(names, buildPkg.TestGoFiles...)
+	}
+	diagnostics, err := checkFiles(fset, imp, dir, names)
+	if err != nil || !tests || len(buildPkg.XTestGoFiles) == 0 {
+		return diagnostics, err
+	}
+
+	// The external test package imports the package under test, which the source importer
+	// resolves on its own, without the in-package test files.
+	xDiagnostics, err := checkFiles(fset, imp, dir, buildPkg.XTestGoFiles)
+	return append(diagnostics, xDiagnostics...), err
+}
+
+func checkFiles(fset *token.FileSet, imp types.Importer, dir string, names []string) ([]Diagnostic, error) {
+	files := make([]*ast.File, 0, len(names))
+	for _, name := range names {
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, fmt.Errorf("resultcheck: %w", err)
+		}
+		files = append(files, file)
+	}
+
+	info := &types.Info{
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+		Instances:  make(map[*ast.Ident]types.Instance),
+	}
+	config := types.Config{Importer: imp}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("resultcheck: %w", err)
+	}
+	if _, err := config.Check(absDir, fset, files, info); err != nil {
+		return nil, fmt.Errorf("resultcheck: %w", err)
+	}
+	return Check(fset, files, info), nil
+}
@@ -0,0 +1,106 @@
+// Command resultcheck reports risky uses of the result and optional packages:
+//
+//   - unwrap: Unwrap, UnwrapErr or MustGet called without a dominating IsOk/IsSome (or IsErr) check
+//     of the same value, either as an enclosing if condition or as an earlier early-return guard;
+//     calls directly in a function named Must..., which panics on failure by convention, are not reported;
+//   - dropped: a call returning result.Result used as a statement, including go and defer statements,
+//     so its error is never looked at; "_ = f()" is an explicit discard and is not reported;
+//   - nilsome: optional.Some(nil) or result.Ok(nil), which always panic;
+//   - compare: Optional values compared with == or !=, instead of optional.Equal.
+//
+// A finding is suppressed by a comment at the end of its line, or by a comment on its own line just above it:
+//
+//	v := r.Unwrap() //resultcheck:ignore unwrap
+//
+//	//resultcheck:ignore unwrap
+//	w := r.Unwrap()
+//
+// Without check names, the comment suppresses every check on that line.
+//
+// Usage:
+//
+//	resultcheck [-tests] [packages]
+//
+// Packages are directories; a trailing "/..." includes all subdirectories. The default is ".".
+// The exit status is 1 if anything was reported.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	tests := flag.Bool("tests", false, "also check _test.go files")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: resultcheck [-tests] [packages]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	patterns := flag.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+
+	dirs, err := expand(patterns)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	found := false
+	for _, dir := range dirs {
+		diagnostics, err := CheckDir(dir, *tests)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		for _, d := range diagnostics {
+			fmt.Println(d)
+			found = true
+		}
+	}
+	if found {
+		os.Exit(1)
+	}
+}
+
+// expand turns the command-line patterns into a list of directories containing Go files.
+func expand(patterns []string) ([]string, error) {
+	var dirs []string
+	for _, pattern := range patterns {
+		root, recursive := strings.CutSuffix(pattern, "/...")
+		if !recursive {
+			dirs = append(dirs, pattern)
+			continue
+		}
+		if root == "" {
+			root = "."
+		}
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() {
+				return nil
+			}
+			name := d.Name()
+			if path != root && (name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			if matches, _ := filepath.Glob(filepath.Join(path, "*.go")); len(matches) > 0 {
+				dirs = append(dirs, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return dirs, nil
+}
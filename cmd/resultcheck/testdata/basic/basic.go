@@ -0,0 +1,140 @@
+package basic
+
+import (
+	"errors"
+	"os"
+
+	"github.com/azat-dev/go-utils/optional"
+	"github.com/azat-dev/go-utils/result"
+)
+
+func load() result.Result[int] {
+	return result.Ok(1)
+}
+
+func unguarded(r result.Result[int], o optional.Optional[string]) {
+	_ = r.Unwrap()      // want `r.Unwrap\(\) may panic: no dominating IsOk\(\) check`
+	_ = r.MustGet()     // want `r.MustGet\(\) may panic`
+	_ = r.UnwrapErr()   // want `r.UnwrapErr\(\) may panic: no dominating IsErr\(\) check`
+	_ = o.Unwrap()      // want `o.Unwrap\(\) may panic: no dominating IsSome\(\) check`
+	_ = load().Unwrap() // want `load\(\).Unwrap\(\) may panic`
+}
+
+func guardedByIf(r result.Result[int], o optional.Optional[string], other result.Result[int]) {
+	if r.IsOk() {
+		_ = r.Unwrap()
+		_ = other.Unwrap() // want `other.Unwrap\(\) may panic`
+	} else {
+		_ = r.UnwrapErr()
+	}
+	if !r.IsErr() && o.IsSome() {
+		_ = r.Unwrap()
+		_ = o.Unwrap()
+	}
+	if r.IsErr() {
+		_ = r.Unwrap() // want `r.Unwrap\(\) may panic`
+	}
+	if r.IsOk() || o.IsSome() {
+		_ = o.Unwrap() // want `o.Unwrap\(\) may panic`
+	}
+	if o.IsNone() {
+		return
+	} else {
+		_ = o.Unwrap()
+	}
+}
+
+func guardedByEarlyReturn(r result.Result[int], o optional.Optional[string]) int {
+	if r.IsErr() {
+		return 0
+	}
+	if !o.IsSome() {
+		panic("missing")
+	}
+	_ = o.Unwrap()
+	return r.Unwrap()
+}
+
+func guardedByEarlyExit(r result.Result[int]) int {
+	if !r.IsOk() {
+		os.Exit(1)
+	}
+	return r.Unwrap()
+}
+
+func guardNotTerminating(r result.Result[int]) int {
+	if r.IsErr() {
+		println("error")
+	}
+	return r.Unwrap() // want `r.Unwrap\(\) may panic`
+}
+
+func guardedByExpression(r result.Result[int], o optional.Optional[int]) bool {
+	return (r.IsOk() && r.Unwrap() > 0) || o.IsNone() || o.Unwrap() > 0
+}
+
+func guardedBySwitch(r result.Result[int]) int {
+	switch {
+	case r.IsOk():
+		return r.Unwrap()
+	case r.IsErr():
+		return r.Unwrap() // want `r.Unwrap\(\) may panic`
+	}
+	return 0
+}
+
+func guardDoesNotCrossClosures(r result.Result[int]) func() int {
+	if r.IsOk() {
+		return func() int {
+			return r.Unwrap() // want `r.Unwrap\(\) may panic`
+		}
+	}
+	return nil
+}
+
+func dropped(r result.Result[int]) {
+	load()                           // want `result of load is not used`
+	result.Err[int](errors.New("x")) // want `result of result.Err\[int\] is not used`
+	r.OrElse(result.Ok(2))           // want `result of r.OrElse is not used`
+	r.InspectErr(func(error) {})
+	_ = load()
+	go load()    // want `result of load is not used`
+	defer load() // want `result of load is not used`
+}
+
+func nilValues() {
+	_ = optional.Some[*int](nil) // want `Some called with nil always panics`
+	_ = result.Ok[error](nil)    // want `Ok called with nil always panics`
+	var p *int
+	_ = optional.NewFromNullable(p)
+}
+
+func compare(a, b optional.Optional[int]) bool {
+	if a == b { // want `Optional compared with ==; use optional.Equal`
+		return true
+	}
+	return a != optional.None[int]() // want `Optional compared with !=`
+}
+
+func suppressed(r result.Result[int], a, b optional.Optional[int]) {
+	_ = r.Unwrap() //resultcheck:ignore
+	//resultcheck:ignore unwrap
+	_ = r.MustGet()
+	load()         //resultcheck:ignore unwrap // want `result of load is not used`
+	_ = a == b     //resultcheck:ignore compare dropped
+	_ = r.Unwrap() //resultcheck:ignore
+	_ = r.Unwrap() // want `r.Unwrap\(\) may panic`
+	if r.IsErr() { //resultcheck:ignore
+		_ = r.Unwrap() // want `r.Unwrap\(\) may panic`
+	}
+}
+
+func MustLoad() int {
+	return load().MustGet()
+}
+
+func mustLoadLater() func() int {
+	return func() int {
+		return load().Unwrap() // want `load\(\).Unwrap\(\) may panic`
+	}
+}
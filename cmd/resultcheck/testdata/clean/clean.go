@@ -0,0 +1,22 @@
+package clean
+
+import "github.com/azat-dev/go-utils/result"
+
+func parse(s string) result.Result[int] {
+	if s == "" {
+		return result.ErrorF[int]("empty")
+	}
+	return result.Ok(len(s))
+}
+
+func Sum(inputs []string) int {
+	total := 0
+	for _, s := range inputs {
+		r := parse(s)
+		if r.IsErr() {
+			continue
+		}
+		total += r.Unwrap()
+	}
+	return total
+}
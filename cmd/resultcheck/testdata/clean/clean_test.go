@@ -0,0 +1,10 @@
+package clean
+
+import "testing"
+
+func TestSum(t *testing.T) {
+	_ = parse("x").MustGet()
+	if Sum([]string{"a"}) != 1 {
+		t.Fatal("unexpected sum")
+	}
+}
@@ -0,0 +1,379 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+const (
+	marker      = "//sumgen:variants"
+	generatedBy = "// Code generated by sumgen; DO NOT EDIT."
+)
+
+// sumInfo describes one annotated interface and the names of the code generated for it.
+type sumInfo struct {
+	Name      string
+	Variants  []string
+	Seal      string
+	IsMethod  string
+	Match     string
+	From      string
+	Marshal   string
+	Unmarshal string
+	Wrapper   string
+	Envelope  string
+}
+
+// annotated is an interface declaration carrying the marker comment.
+type annotated struct {
+	spec     *ast.TypeSpec
+	variants []string
+}
+
+// Generate parses the package in dir and returns the source of the generated file.
+// If typeNames is empty, code is generated for every interface with a sumgen:variants comment.
+func Generate(dir string, typeNames []string) ([]byte, error) {
+	pkg, decls, err := loadPackage(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	names := typeNames
+	if len(names) == 0 {
+		for name := range decls {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+	}
+
+	owners := make(map[string]string)
+	var infos []sumInfo
+	for _, name := range names {
+		decl, ok := decls[name]
+		if !ok {
+			return nil, fmt.Errorf("sumgen: no interface %s with a %s comment in %s", name, marker, dir)
+		}
+		info, err := inspectSum(pkg, decl)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range info.Variants {
+			if owner, ok := owners[v]; ok {
+				return nil, fmt.Errorf("sumgen: %s is a variant of both %s and %s", v, owner, name)
+			}
+			owners[v] = name
+		}
+		infos = append(infos, info)
+	}
+	if len(infos) == 0 {
+		return nil, fmt.Errorf("sumgen: no interfaces with a %s comment in %s", marker, dir)
+	}
+
+	return render(pkg.Name(), infos)
+}
+
+// loadPackage parses and type-checks the Go files of the package in dir, leaving out files generated by sumgen,
+// and returns the annotated interfaces by name.
+func loadPackage(dir string) (*types.Package, map[string]annotated, error) {
+	buildPkg, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, nil, fmt.Errorf("sumgen: %w", err)
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("sumgen: %w", err)
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	decls := make(map[string]annotated)
+	for _, name := range buildPkg.GoFiles {
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, nil, fmt.Errorf("sumgen: %w", err)
+		}
+		if isSumgenOutput(file) {
+			continue
+		}
+		files = append(files, file)
+		if err := collectAnnotated(fset, file, decls); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	config := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		// The annotated interfaces embed the seal from the generated file that was left out,
+		// so type errors are ignored: the variant structs are still fully resolved.
+		Error: func(error) {},
+	}
+	pkg, _ := config.Check(absDir, fset, files, nil)
+	if pkg == nil {
+		return nil, nil, fmt.Errorf("sumgen: failed to type-check %s", dir)
+	}
+	return pkg, decls, nil
+}
+
+func isSumgenOutput(file *ast.File) bool {
+	for _, group := range file.Comments {
+		if group.Pos() > file.Package {
+			break
+		}
+		for _, c := range group.List {
+			if c.Text == generatedBy {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// collectAnnotated adds the type declarations of file that carry the marker comment to decls.
+func collectAnnotated(fset *token.FileSet, file *ast.File, decls map[string]annotated) error {
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			ts := spec.(*ast.TypeSpec)
+			doc := ts.Doc
+			if doc == nil && len(gd.Specs) == 1 {
+				doc = gd.Doc
+			}
+			variants, ok := parseMarker(doc)
+			if !ok {
+				continue
+			}
+			if _, isInterface := ts.Type.(*ast.InterfaceType); !isInterface || ts.TypeParams != nil {
+				return fmt.Errorf("sumgen: %s: %s must be on a non-generic interface", fset.Position(ts.Pos()), marker)
+			}
+			if len(variants) == 0 {
+				return fmt.Errorf("sumgen: %s: %s lists no variants", fset.Position(ts.Pos()), marker)
+			}
+			decls[ts.Name.Name] = annotated{spec: ts, variants: variants}
+		}
+	}
+	return nil
+}
+
+// parseMarker returns the variants listed in a "//sumgen:variants A, B" line of doc, separated by commas or spaces.
+func parseMarker(doc *ast.CommentGroup) ([]string, bool) {
+	if doc == nil {
+		return nil, false
+	}
+	for _, c := range doc.List {
+		rest, ok := strings.CutPrefix(c.Text, marker)
+		if !ok || (rest != "" && rest[0] != ' ' && rest[0] != '\t') {
+			continue
+		}
+		return strings.FieldsFunc(rest, func(r rune) bool { return r == ',' || unicode.IsSpace(r) }), true
+	}
+	return nil, false
+}
+
+// inspectSum checks an annotated interface and its variants and names the generated declarations.
+func inspectSum(pkg *types.Package, decl annotated) (sumInfo, error) {
+	name := decl.spec.Name.Name
+	exported := ast.IsExported(name)
+	info := sumInfo{
+		Name:      name,
+		Variants:  decl.variants,
+		Seal:      "sealed" + exportName(name),
+		IsMethod:  "is" + exportName(name),
+		Match:     localName(exported, "Match"+exportName(name)),
+		From:      localName(exported, exportName(name)+"From"),
+		Marshal:   localName(exported, "Marshal"+exportName(name)+"JSON"),
+		Unmarshal: localName(exported, "Unmarshal"+exportName(name)+"JSON"),
+		Wrapper:   name + "JSON",
+		Envelope:  unexportName(name) + "Envelope",
+	}
+	if !embedsSeal(decl.spec.Type.(*ast.InterfaceType), info.Seal) {
+		return sumInfo{}, fmt.Errorf("sumgen: %s must embed %s, so that only its variants implement it", name, info.Seal)
+	}
+
+	seen := make(map[string]bool)
+	for _, v := range info.Variants {
+		if seen[v] {
+			return sumInfo{}, fmt.Errorf("sumgen: %s lists variant %s twice", name, v)
+		}
+		seen[v] = true
+		obj, ok := pkg.Scope().Lookup(v).(*types.TypeName)
+		if !ok {
+			return sumInfo{}, fmt.Errorf("sumgen: variant %s of %s not found", v, name)
+		}
+		named, ok := obj.Type().(*types.Named)
+		if !ok || named.TypeParams().Len() > 0 {
+			return sumInfo{}, fmt.Errorf("sumgen: variant %s of %s is not a non-generic struct type", v, name)
+		}
+		if _, ok := named.Underlying().(*types.Struct); !ok {
+			return sumInfo{}, fmt.Errorf("sumgen: variant %s of %s is not a non-generic struct type", v, name)
+		}
+	}
+	return info, nil
+}
+
+func embedsSeal(iface *ast.InterfaceType, seal string) bool {
+	for _, f := range iface.Methods.List {
+		if id, ok := f.Type.(*ast.Ident); ok && len(f.Names) == 0 && id.Name == seal {
+			return true
+		}
+	}
+	return false
+}
+
+// localName keeps name exported for exported sum types and unexports it otherwise.
+func localName(exported bool, name string) string {
+	if exported {
+		return name
+	}
+	return unexportName(name)
+}
+
+func exportName(name string) string {
+	runes := []rune(name)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+func unexportName(name string) string {
+	runes := []rune(name)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
+
+func render(pkgName string, infos []sumInfo) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s\n\npackage %s\n\n", generatedBy, pkgName)
+	buf.WriteString("import (\n\t\"encoding/json\"\n\t\"fmt\"\n\n")
+	buf.WriteString("\t\"github.com/azat-dev/go-utils/optional\"\n\t\"github.com/azat-dev/go-utils/result\"\n)\n")
+
+	for _, info := range infos {
+		renderSeal(&buf, info)
+		renderConstructors(&buf, info)
+		renderMatch(&buf, info)
+		renderJSON(&buf, info)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("sumgen: formatting generated code: %w", err)
+	}
+	return src, nil
+}
+
+func renderSeal(buf *bytes.Buffer, info sumInfo) {
+	fmt.Fprintf(buf, "\n// %s is embedded in %s so that only its variants implement it: %s.\n",
+		info.Seal, info.Name, strings.Join(info.Variants, ", "))
+	fmt.Fprintf(buf, "type %s interface {\n\t%s()\n", info.Seal, info.IsMethod)
+	for _, v := range info.Variants {
+		fmt.Fprintf(buf, "\n\t// As%s returns the %s held by the %s, or None if it holds another variant.\n", exportName(v), v, info.Name)
+		fmt.Fprintf(buf, "\tAs%s() optional.Optional[%s]\n", exportName(v), v)
+	}
+	buf.WriteString("}\n")
+
+	for _, v := range info.Variants {
+		fmt.Fprintf(buf, "\nfunc (%s) %s() {}\n", v, info.IsMethod)
+		for _, other := range info.Variants {
+			fmt.Fprintf(buf, "\n// As%s implements %s.\n", exportName(other), info.Name)
+			if other == v {
+				fmt.Fprintf(buf, "func (v %s) As%s() optional.Optional[%s] {\n\treturn optional.Some(v)\n}\n", v, exportName(v), v)
+			} else {
+				fmt.Fprintf(buf, "func (%s) As%s() optional.Optional[%s] {\n\treturn optional.None[%s]()\n}\n",
+					v, exportName(other), other, other)
+			}
+		}
+	}
+}
+
+func renderConstructors(buf *bytes.Buffer, info sumInfo) {
+	for _, v := range info.Variants {
+		fmt.Fprintf(buf, "\n// %s%s returns v as %s.\n", info.From, exportName(v), article(info.Name))
+		fmt.Fprintf(buf, "func %s%s(v %s) %s {\n\treturn v\n}\n", info.From, exportName(v), v, info.Name)
+	}
+}
+
+func renderMatch(buf *bytes.Buffer, info sumInfo) {
+	fmt.Fprintf(buf, "\n// %s calls the handler for the variant held by s and returns its result.\n", info.Match)
+	buf.WriteString("// There is one handler per variant, so adding a variant breaks every call until it is handled.\n")
+	buf.WriteString("// A pointer to a variant, which also implements the interface, is handled like the variant.\n")
+	fmt.Fprintf(buf, "// It panics if s or the pointer it holds is nil.\n")
+	fmt.Fprintf(buf, "func %s[R any](s %s", info.Match, info.Name)
+	for _, v := range info.Variants {
+		fmt.Fprintf(buf, ", on%s func(%s) R", exportName(v), v)
+	}
+	buf.WriteString(") R {\n\tswitch v := s.(type) {\n")
+	for _, v := range info.Variants {
+		fmt.Fprintf(buf, "\tcase %s:\n\t\treturn on%s(v)\n", v, exportName(v))
+		fmt.Fprintf(buf, "\tcase *%s:\n\t\tif v == nil {\n\t\t\tpanic(\"%s called with a nil *%s\")\n\t\t}\n", v, info.Match, v)
+		fmt.Fprintf(buf, "\t\treturn on%s(*v)\n", exportName(v))
+	}
+	fmt.Fprintf(buf, "\tcase nil:\n\t\tpanic(\"%s called with a nil %s\")\n\t}\n", info.Match, info.Name)
+	// Only a struct embedding a variant, or a pointer to one, can get here, as it inherits the seal method.
+	fmt.Fprintf(buf, "\tpanic(fmt.Sprintf(\"%s: unknown %s variant %%T\", s))\n}\n", info.Match, info.Name)
+}
+
+func renderJSON(buf *bytes.Buffer, info sumInfo) {
+	fmt.Fprintf(buf, "\n// %s is the JSON form of %s.\n", info.Envelope, article(info.Name))
+	fmt.Fprintf(buf, "type %s struct {\n\tType  string          `json:\"type\"`\n\tValue json.RawMessage `json:\"value\"`\n}\n", info.Envelope)
+
+	fmt.Fprintf(buf, "\n// %s encodes s as {\"type\": \"<variant>\", \"value\": ...}, where <variant> is the name of the variant type.\n", info.Marshal)
+	buf.WriteString("// A pointer to a variant is encoded like the variant, so it decodes as the variant value.\n")
+	fmt.Fprintf(buf, "func %s(s %s) ([]byte, error) {\n\tvar typ string\n\tswitch v := s.(type) {\n", info.Marshal, info.Name)
+	for _, v := range info.Variants {
+		fmt.Fprintf(buf, "\tcase %s:\n\t\ttyp = %q\n", v, v)
+		fmt.Fprintf(buf, "\tcase *%s:\n\t\tif v == nil {\n\t\t\treturn nil, fmt.Errorf(\"%s: cannot encode a nil *%s\")\n\t\t}\n", v, info.Name, v)
+		fmt.Fprintf(buf, "\t\ttyp = %q\n", v)
+	}
+	fmt.Fprintf(buf, "\tdefault:\n\t\treturn nil, fmt.Errorf(\"%s: cannot encode %%T\", s)\n\t}\n", info.Name)
+	buf.WriteString("\tvalue, err := json.Marshal(s)\n\tif err != nil {\n\t\treturn nil, err\n\t}\n")
+	fmt.Fprintf(buf, "\treturn json.Marshal(%s{Type: typ, Value: value})\n}\n", info.Envelope)
+
+	fmt.Fprintf(buf, "\n// %s decodes %s encoded by %s.\n", info.Unmarshal, article(info.Name), info.Marshal)
+	buf.WriteString("// It fails if the type is missing or unknown, or if the value is null.\n")
+	fmt.Fprintf(buf, "func %s(data []byte) result.Result[%s] {\n", info.Unmarshal, info.Name)
+	fmt.Fprintf(buf, "\tvar raw %s\n\tif err := json.Unmarshal(data, &raw); err != nil {\n\t\treturn result.Err[%s](err)\n\t}\n",
+		info.Envelope, info.Name)
+	fmt.Fprintf(buf, "\tif len(raw.Value) == 0 || string(raw.Value) == \"null\" {\n")
+	fmt.Fprintf(buf, "\t\treturn result.ErrorF[%s](\"%s: missing value for type %%q\", raw.Type)\n\t}\n", info.Name, info.Name)
+	buf.WriteString("\tswitch raw.Type {\n")
+	for _, v := range info.Variants {
+		fmt.Fprintf(buf, "\tcase %q:\n\t\tvar v %s\n", v, v)
+		fmt.Fprintf(buf, "\t\tif err := json.Unmarshal(raw.Value, &v); err != nil {\n\t\t\treturn result.Err[%s](err)\n\t\t}\n", info.Name)
+		fmt.Fprintf(buf, "\t\treturn result.Ok[%s](v)\n", info.Name)
+	}
+	buf.WriteString("\t}\n")
+	fmt.Fprintf(buf, "\treturn result.ErrorF[%s](\"%s: unknown type %%q\", raw.Type)\n}\n", info.Name, info.Name)
+
+	fmt.Fprintf(buf, "\n// %s holds %s in values encoded with encoding/json, which cannot decode into an interface.\n", info.Wrapper, article(info.Name))
+	buf.WriteString("// A nil Value is encoded as null.\n")
+	fmt.Fprintf(buf, "type %s struct {\n\tValue %s\n}\n", info.Wrapper, info.Name)
+
+	fmt.Fprintf(buf, "\n// MarshalJSON encodes the %s with %s.\n", info.Name, info.Marshal)
+	fmt.Fprintf(buf, "func (j %s) MarshalJSON() ([]byte, error) {\n", info.Wrapper)
+	fmt.Fprintf(buf, "\tif j.Value == nil {\n\t\treturn []byte(\"null\"), nil\n\t}\n\treturn %s(j.Value)\n}\n", info.Marshal)
+
+	fmt.Fprintf(buf, "\n// UnmarshalJSON decodes the %s with %s. null leaves Value nil.\n", info.Name, info.Unmarshal)
+	fmt.Fprintf(buf, "func (j *%s) UnmarshalJSON(data []byte) error {\n", info.Wrapper)
+	buf.WriteString("\tif string(data) == \"null\" {\n\t\tj.Value = nil\n\t\treturn nil\n\t}\n")
+	fmt.Fprintf(buf, "\tvalue, err := %s(data).Get()\n\tif err != nil {\n\t\treturn err\n\t}\n\tj.Value = value\n\treturn nil\n}\n", info.Unmarshal)
+}
+
+// article prefixes name with "a" or "an" for doc comments.
+func article(name string) string {
+	if strings.ContainsRune("aeiouAEIOU", []rune(name)[0]) {
+		return "an " + name
+	}
+	return "a " + name
+}
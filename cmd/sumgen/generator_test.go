@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"go/ast"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files")
+
+// checkGolden compares got with the golden file, or rewrites it when -update is set.
+func checkGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("Generated code differs from %s; run go test -update to refresh it.\ngot:\n%s", path, got)
+	}
+}
+
+// runPackageTests runs the tests of dir with the generated file added through an overlay,
+// so testdata stays untouched and the generated code is exercised, not just compiled.
+func runPackageTests(t *testing.T, dir string, src []byte) {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("skipping compilation in short mode")
+	}
+	tmp := t.TempDir()
+	generated := filepath.Join(tmp, "generated.go")
+	if err := os.WriteFile(generated, src, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	overlay, err := json.Marshal(map[string]map[string]string{
+		"Replace": {filepath.Join(absDir, "zz_sumgen.go"): generated},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	overlayPath := filepath.Join(tmp, "overlay.json")
+	if err := os.WriteFile(overlayPath, overlay, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command("go", "test", "-overlay="+overlayPath, "./"+filepath.ToSlash(dir))
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Errorf("Generated code does not pass the package tests: %v\n%s", err, out)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	t.Run("all annotated interfaces", func(t *testing.T) {
+		src, err := Generate("testdata/shapes", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkGolden(t, "shapes.golden", src)
+		runPackageTests(t, "testdata/shapes", src)
+	})
+
+	t.Run("selected interface", func(t *testing.T) {
+		src, err := Generate("testdata/shapes", []string{"event"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(src), "Shape") {
+			t.Errorf("Expected only event code, got:\n%s", src)
+		}
+		for _, name := range []string{"func matchEvent[R any](", "func eventFromStarted(", "func unmarshalEventJSON(", "type eventJSON struct"} {
+			if !strings.Contains(string(src), name) {
+				t.Errorf("Expected generated code to contain %q", name)
+			}
+		}
+	})
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		dir   string
+		types []string
+		msg   string
+	}{
+		{"unknown interface", "testdata/shapes", []string{"Polygon"}, "no interface Polygon with a //sumgen:variants comment"},
+		{"missing seal", "testdata/invalid", []string{"Unsealed"}, "Unsealed must embed sealedUnsealed"},
+		{"unknown variant", "testdata/invalid", []string{"MissingVariant"}, "variant Missing of MissingVariant not found"},
+		{"variant not a struct", "testdata/invalid", []string{"NotStruct"}, "variant ID of NotStruct is not a non-generic struct type"},
+		{"duplicate variant", "testdata/invalid", []string{"Twice"}, "Twice lists variant A twice"},
+		{"shared variant", "testdata/invalid", []string{"First", "Second"}, "A is a variant of both First and Second"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Generate(tt.dir, tt.types)
+			if err == nil || !strings.Contains(err.Error(), tt.msg) {
+				t.Errorf("Expected error containing '%s', got %v", tt.msg, err)
+			}
+		})
+	}
+}
+
+func TestParseMarker(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+		ok   bool
+	}{
+		{"//sumgen:variants A, B", "A B", true},
+		{"//sumgen:variants A B\tC", "A B C", true},
+		{"//sumgen:variants", "", true},
+		{"//sumgen:variantsA", "", false},
+		{"// sumgen:variants A", "", false},
+	}
+	for _, tt := range tests {
+		variants, ok := parseMarker(&ast.CommentGroup{List: []*ast.Comment{{Text: tt.text}}})
+		if ok != tt.ok || strings.Join(variants, " ") != tt.want {
+			t.Errorf("parseMarker(%q) = %v, %v; expected %q, %v", tt.text, variants, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+// Command sumgen generates sum types from an interface and its variant structs.
+//
+// The interface lists its variants in a marker comment and embeds the seal that sumgen generates:
+//
+//	//sumgen:variants Circle, Square
+//	type Shape interface {
+//		sealedShape
+//		Area() float64
+//	}
+//
+// For every annotated interface T it writes:
+//
+//   - the sealed interface sealedT, implemented only by the variants, with an AsV() optional.Optional[V]
+//     method per variant V, so every T has them;
+//   - a constructor TFromV(v V) T per variant;
+//   - MatchT(t, onV1, onV2, ...) calling the handler for the variant held by t: it needs a handler for
+//     every variant, so a new variant breaks the build until every Match handles it;
+//   - MarshalTJSON and UnmarshalTJSON, encoding a T as {"type": "<variant>", "value": ...},
+//     and a TJSON wrapper for struct fields, since encoding/json cannot decode into an interface.
+//
+// For unexported interfaces the functions are unexported as well.
+//
+// Usage, typically from a go:generate directive in the package:
+//
+//	//go:generate go run github.com/azat-dev/go-utils/cmd/sumgen
+//
+// Without -type, code is generated for every annotated interface in the package.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	typeNames := flag.String("type", "", "comma-separated list of interface names; default is every annotated interface")
+	output := flag.String("output", "", "output file name; default is <package>_sumgen.go in the package directory")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: sumgen [-type=A,B] [-output=file] [directory]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+
+	var names []string
+	if *typeNames != "" {
+		names = strings.Split(*typeNames, ",")
+	}
+
+	src, err := Generate(dir, names)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	path := *output
+	if path == "" {
+		path = filepath.Join(dir, packageFileName(dir)+"_sumgen.go")
+	}
+	if err := os.WriteFile(path, src, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// packageFileName returns the package name set by go generate, or the directory name.
+func packageFileName(dir string) string {
+	if name := os.Getenv("GOPACKAGE"); name != "" {
+		return name
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "generated"
+	}
+	return strings.ToLower(filepath.Base(abs))
+}
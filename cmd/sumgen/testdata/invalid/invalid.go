@@ -0,0 +1,35 @@
+package invalid
+
+//sumgen:variants A
+type Unsealed interface {
+	Name() string
+}
+
+//sumgen:variants A, Missing
+type MissingVariant interface {
+	sealedMissingVariant
+}
+
+//sumgen:variants A, ID
+type NotStruct interface {
+	sealedNotStruct
+}
+
+//sumgen:variants A, A
+type Twice interface {
+	sealedTwice
+}
+
+//sumgen:variants A
+type First interface {
+	sealedFirst
+}
+
+//sumgen:variants A
+type Second interface {
+	sealedSecond
+}
+
+type A struct{}
+
+type ID int
@@ -0,0 +1,63 @@
+package shapes
+
+import "math"
+
+//sumgen:variants Circle, Square, Rect
+type Shape interface {
+	sealedShape
+	Area() float64
+}
+
+type Circle struct {
+	Radius float64 `json:"radius"`
+}
+
+func (c Circle) Area() float64 {
+	return math.Pi * c.Radius * c.Radius
+}
+
+type Square struct {
+	Side float64 `json:"side"`
+}
+
+func (s Square) Area() float64 {
+	return s.Side * s.Side
+}
+
+type Rect struct {
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+func (r Rect) Area() float64 {
+	return r.Width * r.Height
+}
+
+// Drawing stores a Shape in JSON through the generated wrapper.
+type Drawing struct {
+	Name  string    `json:"name"`
+	Shape ShapeJSON `json:"shape"`
+}
+
+// event has no methods besides the seal and is unexported, so are its generated functions.
+//
+//sumgen:variants started finished
+type event interface {
+	sealedEvent
+}
+
+type started struct {
+	At int
+}
+
+type finished struct {
+	At  int
+	Err string
+}
+
+func describe(e event) string {
+	return matchEvent(e,
+		func(s started) string { return "started" },
+		func(f finished) string { return "finished" },
+	)
+}
@@ -0,0 +1,135 @@
+package shapes
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// These tests exercise the generated code; they run from the sumgen tests with the generated file overlaid.
+
+func TestMatch(t *testing.T) {
+	shapes := []Shape{ShapeFromCircle(Circle{Radius: 1}), ShapeFromSquare(Square{Side: 2}), ShapeFromRect(Rect{Width: 2, Height: 3})}
+	var names []string
+	for _, s := range shapes {
+		names = append(names, MatchShape(s,
+			func(Circle) string { return "circle" },
+			func(Square) string { return "square" },
+			func(Rect) string { return "rect" },
+		))
+	}
+	if got := names[0] + "," + names[1] + "," + names[2]; got != "circle,square,rect" {
+		t.Errorf("Expected 'circle,square,rect', got '%s'", got)
+	}
+	if describe(finished{At: 1}) != "finished" {
+		t.Error("Expected unexported match to dispatch to finished")
+	}
+}
+
+func TestMatchPointer(t *testing.T) {
+	var s Shape = &Square{Side: 2}
+	side := MatchShape(s,
+		func(Circle) float64 { return 0 },
+		func(sq Square) float64 { return sq.Side },
+		func(Rect) float64 { return 0 },
+	)
+	if side != 2 {
+		t.Errorf("Expected 2, got %v", side)
+	}
+
+	defer func() {
+		if recover() != "MatchShape called with a nil *Square" {
+			t.Error("Expected MatchShape to panic for a nil *Square")
+		}
+	}()
+	var nilSquare *Square
+	MatchShape(Shape(nilSquare), func(Circle) int { return 0 }, func(Square) int { return 0 }, func(Rect) int { return 0 })
+}
+
+func TestMatchNil(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected MatchShape to panic for nil")
+		}
+	}()
+	MatchShape(nil, func(Circle) int { return 0 }, func(Square) int { return 0 }, func(Rect) int { return 0 })
+}
+
+func TestAs(t *testing.T) {
+	var s Shape = Square{Side: 2}
+	if sq, ok := s.AsSquare().Get(); !ok || sq.Side != 2 {
+		t.Errorf("Expected Some(Square{2}), got %v, %v", sq, ok)
+	}
+	if s.AsCircle().IsSome() || s.AsRect().IsSome() {
+		t.Error("Expected None for other variants")
+	}
+}
+
+func TestJSON(t *testing.T) {
+	data, err := MarshalShapeJSON(Rect{Width: 2, Height: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"type":"Rect","value":{"width":2,"height":3}}`
+	if string(data) != want {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+
+	s, err := UnmarshalShapeJSON(data).Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != (Rect{Width: 2, Height: 3}) {
+		t.Errorf("Expected Rect{2 3}, got %#v", s)
+	}
+
+	for _, bad := range []string{`{"type":"Hexagon","value":{}}`, `{"type":"Rect"}`, `{"type":"Rect","value":null}`, `[]`} {
+		if UnmarshalShapeJSON([]byte(bad)).IsOk() {
+			t.Errorf("Expected an error for %s", bad)
+		}
+	}
+	if _, err := MarshalShapeJSON(nil); err == nil {
+		t.Error("Expected an error encoding a nil Shape")
+	}
+}
+
+func TestJSONPointer(t *testing.T) {
+	data, err := MarshalShapeJSON(&Circle{Radius: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"type":"Circle","value":{"radius":1}}`
+	if string(data) != want {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+	var nilCircle *Circle
+	if _, err := MarshalShapeJSON(nilCircle); err == nil {
+		t.Error("Expected an error encoding a nil *Circle")
+	}
+}
+
+func TestWrapperJSON(t *testing.T) {
+	in := Drawing{Name: "logo", Shape: ShapeJSON{Value: Circle{Radius: 1}}}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"name":"logo","shape":{"type":"Circle","value":{"radius":1}}}`
+	if string(data) != want {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+
+	var out Drawing
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Shape.Value != (Circle{Radius: 1}) {
+		t.Errorf("Expected Circle{1}, got %#v", out.Shape.Value)
+	}
+
+	if err := json.Unmarshal([]byte(`{"name":"empty","shape":null}`), &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Shape.Value != nil {
+		t.Errorf("Expected nil Shape for null, got %#v", out.Shape.Value)
+	}
+}
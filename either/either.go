@@ -0,0 +1,171 @@
+package either
+
+import (
+	"encoding/json"
+	"fmt"
+
+	go_utils "github.com/azat-dev/go-utils"
+
+	"github.com/azat-dev/go-utils/optional"
+	"github.com/azat-dev/go-utils/result"
+)
+
+// Either holds exactly one of two values: a Left of type L or a Right of type R.
+// By convention, when used for success or failure, Right is the success.
+// The zero Either is a Left holding the zero value of L, which may be nil.
+type Either[L, R any] struct {
+	left    L
+	right   R
+	isRight bool
+}
+
+// Left creates an Either holding a left value.
+// Panics if the value is nil (for interface and pointer types).
+func Left[L, R any](v L) Either[L, R] {
+	if go_utils.IsNil(v) {
+		panic("Left() called with nil value")
+	}
+	var zero R
+	return Either[L, R]{left: v, right: zero, isRight: false}
+}
+
+// Right creates an Either holding a right value.
+// Panics if the value is nil (for interface and pointer types).
+func Right[L, R any](v R) Either[L, R] {
+	if go_utils.IsNil(v) {
+		panic("Right() called with nil value")
+	}
+	var zero L
+	return Either[L, R]{left: zero, right: v, isRight: true}
+}
+
+// IsLeft returns true if the Either holds a left value.
+func (e Either[L, R]) IsLeft() bool {
+	return !e.isRight
+}
+
+// IsRight returns true if the Either holds a right value.
+func (e Either[L, R]) IsRight() bool {
+	return e.isRight
+}
+
+// LeftValue returns Some with the left value, or None if the Either is Right
+// or the left value is nil, as in the zero Either.
+func (e Either[L, R]) LeftValue() optional.Optional[L] {
+	if e.isRight {
+		return optional.None[L]()
+	}
+	return optional.NewFromNullable(e.left)
+}
+
+// RightValue returns Some with the right value, or None if the Either is Left
+// or the right value is nil, as in a swapped zero Either.
+func (e Either[L, R]) RightValue() optional.Optional[R] {
+	if !e.isRight {
+		return optional.None[R]()
+	}
+	return optional.NewFromNullable(e.right)
+}
+
+// Swap returns an Either with the sides exchanged.
+func (e Either[L, R]) Swap() Either[R, L] {
+	return Either[R, L]{left: e.right, right: e.left, isRight: !e.isRight}
+}
+
+// MapLeft applies a function to the left value, if present. A Right is returned unchanged.
+func MapLeft[L, R, U any](e Either[L, R], f func(L) U) Either[U, R] {
+	if e.isRight {
+		var zero U
+		return Either[U, R]{left: zero, right: e.right, isRight: true}
+	}
+	return Left[U, R](f(e.left))
+}
+
+// MapRight applies a function to the right value, if present. A Left is returned unchanged.
+func MapRight[L, R, U any](e Either[L, R], f func(R) U) Either[L, U] {
+	if e.isRight {
+		return Right[L](f(e.right))
+	}
+	var zero U
+	return Either[L, U]{left: e.left, right: zero, isRight: false}
+}
+
+// Fold reduces the Either to a single value by calling onLeft or onRight, depending on the side it holds.
+func Fold[L, R, U any](e Either[L, R], onLeft func(L) U, onRight func(R) U) U {
+	if e.isRight {
+		return onRight(e.right)
+	}
+	return onLeft(e.left)
+}
+
+// FromResult converts a Result into an Either, with the error on the Left and the value on the Right.
+func FromResult[T any](r result.Result[T]) Either[error, T] {
+	v, err := r.Get()
+	if err != nil {
+		return Left[error, T](err)
+	}
+	return Right[error](v)
+}
+
+// ToResult converts an Either with an error on the Left into a Result.
+func ToResult[T any](e Either[error, T]) result.Result[T] {
+	if e.isRight {
+		return result.Ok(e.right)
+	}
+	return result.Err[T](e.left)
+}
+
+const (
+	sideLeft  = "left"
+	sideRight = "right"
+)
+
+type jsonEither struct {
+	Side  string          `json:"side"`
+	Value json.RawMessage `json:"value"`
+}
+
+// MarshalJSON encodes the Either as {"side": "left" | "right", "value": ...}.
+func (e Either[L, R]) MarshalJSON() ([]byte, error) {
+	var side string
+	var value any
+	if e.isRight {
+		side, value = sideRight, e.right
+	} else {
+		side, value = sideLeft, e.left
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(jsonEither{Side: side, Value: raw})
+}
+
+// UnmarshalJSON decodes an Either encoded by MarshalJSON.
+// It fails if the side is missing or unknown, or if the value is null.
+func (e *Either[L, R]) UnmarshalJSON(data []byte) error {
+	var raw jsonEither
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw.Value) == 0 || string(raw.Value) == "null" {
+		return fmt.Errorf("either: missing value for side %q", raw.Side)
+	}
+	switch raw.Side {
+	case sideLeft:
+		var v L
+		if err := json.Unmarshal(raw.Value, &v); err != nil {
+			return err
+		}
+		*e = Left[L, R](v)
+	case sideRight:
+		var v R
+		if err := json.Unmarshal(raw.Value, &v); err != nil {
+			return err
+		}
+		*e = Right[L](v)
+	default:
+		return fmt.Errorf("either: unknown side %q", raw.Side)
+	}
+	return nil
+}
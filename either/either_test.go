@@ -0,0 +1,166 @@
+package either
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/azat-dev/go-utils/result"
+)
+
+type redirect struct {
+	URL string `json:"url"`
+}
+
+func TestLeftRight(t *testing.T) {
+	l := Left[string, int]("hit")
+	if !l.IsLeft() || l.IsRight() {
+		t.Error("Expected Left to be left")
+	}
+	if v := l.LeftValue().Unwrap(); v != "hit" {
+		t.Errorf("Expected 'hit', got '%s'", v)
+	}
+	if l.RightValue().IsSome() {
+		t.Error("Expected RightValue of Left to be None")
+	}
+
+	r := Right[string](7)
+	if !r.IsRight() || r.IsLeft() {
+		t.Error("Expected Right to be right")
+	}
+	if v := r.RightValue().Unwrap(); v != 7 {
+		t.Errorf("Expected 7, got %d", v)
+	}
+	if r.LeftValue().IsSome() {
+		t.Error("Expected LeftValue of Right to be None")
+	}
+}
+
+func TestNilPanics(t *testing.T) {
+	t.Run("Left", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r != "Left() called with nil value" {
+				t.Errorf("Expected Left(nil) to panic, got %v", r)
+			}
+		}()
+		Left[*int, int](nil)
+	})
+
+	t.Run("Right", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r != "Right() called with nil value" {
+				t.Errorf("Expected Right(nil) to panic, got %v", r)
+			}
+		}()
+		Right[int, *int](nil)
+	})
+}
+
+func TestZero(t *testing.T) {
+	var zero Either[error, *int]
+	if !zero.IsLeft() {
+		t.Error("Expected the zero Either to be Left")
+	}
+	if zero.LeftValue().IsSome() || zero.RightValue().IsSome() {
+		t.Error("Expected LeftValue and RightValue of the zero Either to be None")
+	}
+	if zero.Swap().RightValue().IsSome() {
+		t.Error("Expected RightValue of the swapped zero Either to be None")
+	}
+	mapped := MapRight(zero, func(*int) int { return 1 })
+	if !mapped.IsLeft() || mapped.LeftValue().IsSome() {
+		t.Errorf("Expected MapRight to keep the zero Left, got %+v", mapped)
+	}
+}
+
+func TestSwap(t *testing.T) {
+	swapped := Left[string, int]("a").Swap()
+	if !swapped.IsRight() || swapped.RightValue().Unwrap() != "a" {
+		t.Errorf("Expected Right('a'), got %+v", swapped)
+	}
+}
+
+func TestMap(t *testing.T) {
+	length := func(s string) int { return len(s) }
+	double := func(n int) int { return n * 2 }
+
+	if v := MapLeft(Left[string, int]("abc"), length).LeftValue().Unwrap(); v != 3 {
+		t.Errorf("Expected 3, got %d", v)
+	}
+	if v := MapLeft(Right[string](5), length).RightValue().Unwrap(); v != 5 {
+		t.Errorf("Expected Right to pass through MapLeft, got %d", v)
+	}
+	if v := MapRight(Right[string](5), double).RightValue().Unwrap(); v != 10 {
+		t.Errorf("Expected 10, got %d", v)
+	}
+	if v := MapRight(Left[string, int]("abc"), double).LeftValue().Unwrap(); v != "abc" {
+		t.Errorf("Expected Left to pass through MapRight, got '%s'", v)
+	}
+}
+
+func TestFold(t *testing.T) {
+	describe := func(e Either[string, int]) string {
+		return Fold(e, func(s string) string { return "left:" + s }, func(n int) string { return "right:" + strconv.Itoa(n) })
+	}
+	if s := describe(Left[string, int]("a")); s != "left:a" {
+		t.Errorf("Expected 'left:a', got '%s'", s)
+	}
+	if s := describe(Right[string](1)); s != "right:1" {
+		t.Errorf("Expected 'right:1', got '%s'", s)
+	}
+}
+
+func TestResultConversion(t *testing.T) {
+	testErr := errors.New("test error")
+
+	if v := FromResult(result.Ok(1)).RightValue().Unwrap(); v != 1 {
+		t.Errorf("Expected Right(1), got %d", v)
+	}
+	if err := FromResult(result.Err[int](testErr)).LeftValue().Unwrap(); err != testErr {
+		t.Errorf("Expected Left(testErr), got %v", err)
+	}
+	if v := ToResult(Right[error](2)).Unwrap(); v != 2 {
+		t.Errorf("Expected Ok(2), got %d", v)
+	}
+	if !ToResult(Left[error, int](testErr)).ErrIs(testErr) {
+		t.Error("Expected Err(testErr)")
+	}
+}
+
+func TestJSON(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		values := []Either[redirect, int]{Left[redirect, int](redirect{URL: "/next"}), Right[redirect](42)}
+		data, err := json.Marshal(values)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := `[{"side":"left","value":{"url":"/next"}},{"side":"right","value":42}]`
+		if string(data) != expected {
+			t.Errorf("Expected %s, got %s", expected, data)
+		}
+
+		var decoded []Either[redirect, int]
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		if decoded[0].LeftValue().Unwrap().URL != "/next" || decoded[1].RightValue().Unwrap() != 42 {
+			t.Errorf("Unexpected decoded values %+v", decoded)
+		}
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		for _, input := range []string{
+			`{"side":"middle","value":1}`,
+			`{"side":"right"}`,
+			`{"side":"right","value":null}`,
+			`{"side":"right","value":"text"}`,
+			`[]`,
+		} {
+			var e Either[string, int]
+			if err := json.Unmarshal([]byte(input), &e); err == nil {
+				t.Errorf("Expected error for %s", input)
+			}
+		}
+	})
+}
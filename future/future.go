@@ -0,0 +1,192 @@
+package future
+
+import (
+	"context"
+	"errors"
+
+	"github.com/azat-dev/go-utils/optional"
+	"github.com/azat-dev/go-utils/result"
+)
+
+// ErrNoFutures is returned by Any and Race when called without futures.
+var ErrNoFutures = errors.New("no futures given")
+
+// Future is the eventual Result of a function running in its own goroutine.
+type Future[T any] struct {
+	done   chan struct{}
+	result result.Result[T]
+}
+
+// Go runs f in a new goroutine and returns a Future for its Result.
+// ctx is passed to f, so cancelling it lets f stop early.
+// A panic inside f is recovered and stored as an Err holding a *result.PanicError.
+func Go[T any](ctx context.Context, f func(context.Context) result.Result[T]) *Future[T] {
+	fut := &Future[T]{
+		done:   make(chan struct{}),
+		result: result.Result[T]{},
+	}
+	go func() {
+		defer close(fut.done)
+		fut.result = run(ctx, f)
+	}()
+	return fut
+}
+
+// run calls f and converts a panic into an Err.
+func run[T any](ctx context.Context, f func(context.Context) result.Result[T]) (r result.Result[T]) {
+	defer result.Catch(&r)
+	return f(ctx)
+}
+
+// Done returns a channel that is closed when the Result is available.
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// Await blocks until the Result is available or ctx is done.
+// If ctx is done first, it returns an Err holding context.Cause(ctx); the future keeps running.
+func (f *Future[T]) Await(ctx context.Context) result.Result[T] {
+	select {
+	case <-f.done:
+		return f.result
+	case <-ctx.Done():
+		return result.Err[T](context.Cause(ctx))
+	}
+}
+
+// TryGet returns the Result without blocking, or None if it is not available yet.
+func (f *Future[T]) TryGet() optional.Optional[result.Result[T]] {
+	select {
+	case <-f.done:
+		return optional.Some(f.result)
+	default:
+		return optional.None[result.Result[T]]()
+	}
+}
+
+// Then runs g with the value of f once f completes with Ok.
+// If f completes with Err, the returned Future holds the same error and g is not called.
+func Then[T, U any](
+	ctx context.Context,
+	f *Future[T],
+	g func(context.Context, T) result.Result[U],
+) *Future[U] {
+	return Go(ctx, func(ctx context.Context) result.Result[U] {
+		return result.FlatMapResult(f.Await(ctx), func(v T) result.Result[U] {
+			return g(ctx, v)
+		})
+	})
+}
+
+// All waits for every future and returns their values in the order of fs.
+// It completes with the first Err as soon as any future fails.
+func All[T any](ctx context.Context, fs ...*Future[T]) *Future[[]T] {
+	return Go(ctx, func(ctx context.Context) result.Result[[]T] {
+		values := make([]T, len(fs))
+		err := settle(ctx, fs, func(i int, r result.Result[T]) (bool, error) {
+			v, err := r.Get()
+			values[i] = v
+			return err != nil, err
+		})
+		if err != nil {
+			return result.Err[[]T](err)
+		}
+		return result.Ok(values)
+	})
+}
+
+// AllSettled waits for every future and returns all their Results in the order of fs.
+// It only fails if ctx is done before all futures complete.
+func AllSettled[T any](ctx context.Context, fs ...*Future[T]) *Future[[]result.Result[T]] {
+	return Go(ctx, func(ctx context.Context) result.Result[[]result.Result[T]] {
+		results := make([]result.Result[T], len(fs))
+		err := settle(ctx, fs, func(i int, r result.Result[T]) (bool, error) {
+			results[i] = r
+			return false, nil
+		})
+		if err != nil {
+			return result.Err[[]result.Result[T]](err)
+		}
+		return result.Ok(results)
+	})
+}
+
+// Any completes with the first Ok value among fs.
+// If every future fails, it completes with an Err joining all their errors in the order of fs.
+func Any[T any](ctx context.Context, fs ...*Future[T]) *Future[T] {
+	return Go(ctx, func(ctx context.Context) result.Result[T] {
+		if len(fs) == 0 {
+			return result.Err[T](ErrNoFutures)
+		}
+		first := optional.None[T]()
+		errs := make([]error, len(fs))
+		err := settle(ctx, fs, func(i int, r result.Result[T]) (bool, error) {
+			if r.IsOk() {
+				first = r.ToOptional()
+				return true, nil
+			}
+			errs[i] = r.UnwrapErr()
+			return false, nil
+		})
+		if err != nil {
+			return result.Err[T](err)
+		}
+		if v, ok := first.Get(); ok {
+			return result.Ok(v)
+		}
+		return result.Err[T](errors.Join(errs...))
+	})
+}
+
+// Race completes with the Result of the first future to complete, whether Ok or Err.
+func Race[T any](ctx context.Context, fs ...*Future[T]) *Future[T] {
+	return Go(ctx, func(ctx context.Context) result.Result[T] {
+		if len(fs) == 0 {
+			return result.Err[T](ErrNoFutures)
+		}
+		var first result.Result[T]
+		err := settle(ctx, fs, func(_ int, r result.Result[T]) (bool, error) {
+			first = r
+			return true, nil
+		})
+		if err != nil {
+			return result.Err[T](err)
+		}
+		return first
+	})
+}
+
+// settle calls visit with the index and Result of each future in completion order,
+// until visit asks to stop, every future has completed or ctx is done.
+// It returns the error given by visit when stopping, or context.Cause(ctx).
+func settle[T any](
+	ctx context.Context,
+	fs []*Future[T],
+	visit func(int, result.Result[T]) (bool, error),
+) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	completed := make(chan int, len(fs))
+	for i, f := range fs {
+		go func() {
+			select {
+			case <-f.done:
+				completed <- i
+			case <-ctx.Done():
+			}
+		}()
+	}
+
+	for range fs {
+		select {
+		case i := <-completed:
+			if stop, err := visit(i, fs[i].result); stop {
+				return err
+			}
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		}
+	}
+	return nil
+}
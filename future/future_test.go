@@ -0,0 +1,232 @@
+package future
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/azat-dev/go-utils/result"
+)
+
+// checkNoLeak fails the test if goroutines started during it are still running when it ends.
+func checkNoLeak(t *testing.T) {
+	t.Helper()
+	before := runtime.NumGoroutine()
+	t.Cleanup(func() {
+		deadline := time.Now().Add(time.Second)
+		for runtime.NumGoroutine() > before {
+			if time.Now().After(deadline) {
+				t.Errorf("Expected %d goroutines, got %d", before, runtime.NumGoroutine())
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+	})
+}
+
+// value returns a function that waits for release and then returns Ok(v).
+func value[T any](v T, release <-chan struct{}) func(context.Context) result.Result[T] {
+	return func(ctx context.Context) result.Result[T] {
+		select {
+		case <-release:
+			return result.Ok(v)
+		case <-ctx.Done():
+			return result.Err[T](ctx.Err())
+		}
+	}
+}
+
+// failure returns a function that waits for release and then returns Err(err).
+func failure[T any](err error, release <-chan struct{}) func(context.Context) result.Result[T] {
+	return func(ctx context.Context) result.Result[T] {
+		select {
+		case <-release:
+			return result.Err[T](err)
+		case <-ctx.Done():
+			return result.Err[T](ctx.Err())
+		}
+	}
+}
+
+func closed() chan struct{} {
+	ch := make(chan struct{})
+	close(ch)
+	return ch
+}
+
+func TestGo(t *testing.T) {
+	checkNoLeak(t)
+	ctx := context.Background()
+
+	t.Run("Await returns the Result", func(t *testing.T) {
+		f := Go(ctx, value(42, closed()))
+		if v := f.Await(ctx).Unwrap(); v != 42 {
+			t.Errorf("Expected 42, got %d", v)
+		}
+		select {
+		case <-f.Done():
+		default:
+			t.Error("Expected Done to be closed after Await")
+		}
+	})
+
+	t.Run("TryGet before and after completion", func(t *testing.T) {
+		release := make(chan struct{})
+		f := Go(ctx, value("x", release))
+		if f.TryGet().IsSome() {
+			t.Error("Expected TryGet to return None before completion")
+		}
+		close(release)
+		<-f.Done()
+		if v := f.TryGet().Unwrap().Unwrap(); v != "x" {
+			t.Errorf("Expected 'x', got '%s'", v)
+		}
+	})
+
+	t.Run("panic becomes Err", func(t *testing.T) {
+		f := Go(ctx, func(context.Context) result.Result[int] { panic("boom") })
+		if result.ErrAs[*result.PanicError](f.Await(ctx)).IsNone() {
+			t.Error("Expected panic to be recovered into *PanicError")
+		}
+	})
+
+	t.Run("Await honours context", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+		f := Go(ctx, value(1, release))
+		awaitCtx, cancel := context.WithCancel(ctx)
+		cancel()
+		if !f.Await(awaitCtx).ErrIs(context.Canceled) {
+			t.Error("Expected Await to return context.Canceled")
+		}
+	})
+
+	t.Run("cancellation reaches the function", func(t *testing.T) {
+		fCtx, cancel := context.WithCancel(ctx)
+		f := Go(fCtx, value(1, make(chan struct{})))
+		cancel()
+		if !f.Await(ctx).ErrIs(context.Canceled) {
+			t.Error("Expected function to observe cancellation")
+		}
+	})
+}
+
+func TestThen(t *testing.T) {
+	checkNoLeak(t)
+	ctx := context.Background()
+	double := func(_ context.Context, v int) result.Result[int] { return result.Ok(v * 2) }
+
+	if v := Then(ctx, Go(ctx, value(21, closed())), double).Await(ctx).Unwrap(); v != 42 {
+		t.Errorf("Expected 42, got %d", v)
+	}
+
+	testErr := errors.New("test error")
+	if !Then(ctx, Go(ctx, failure[int](testErr, closed())), double).Await(ctx).ErrIs(testErr) {
+		t.Error("Expected Then to propagate the Err")
+	}
+}
+
+func TestAll(t *testing.T) {
+	checkNoLeak(t)
+	ctx := context.Background()
+
+	t.Run("values in input order", func(t *testing.T) {
+		first := make(chan struct{})
+		second := make(chan struct{})
+		all := All(ctx, Go(ctx, value(1, first)), Go(ctx, value(2, second)))
+		close(second)
+		close(first)
+		values := all.Await(ctx).Unwrap()
+		if len(values) != 2 || values[0] != 1 || values[1] != 2 {
+			t.Errorf("Expected [1 2], got %v", values)
+		}
+	})
+
+	t.Run("fails fast", func(t *testing.T) {
+		testErr := errors.New("test error")
+		pending := make(chan struct{})
+		defer close(pending)
+		all := All(ctx, Go(ctx, value(1, pending)), Go(ctx, failure[int](testErr, closed())))
+		if !all.Await(ctx).ErrIs(testErr) {
+			t.Error("Expected All to fail with the first error")
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		if values := All[int](ctx).Await(ctx).Unwrap(); len(values) != 0 {
+			t.Errorf("Expected empty slice, got %v", values)
+		}
+	})
+}
+
+func TestAllSettled(t *testing.T) {
+	checkNoLeak(t)
+	ctx := context.Background()
+	testErr := errors.New("test error")
+
+	results := AllSettled(ctx, Go(ctx, failure[int](testErr, closed())), Go(ctx, value(2, closed()))).
+		Await(ctx).
+		Unwrap()
+	if len(results) != 2 || !results[0].ErrIs(testErr) || results[1].Unwrap() != 2 {
+		t.Errorf("Unexpected results %v", results)
+	}
+
+	t.Run("context done", func(t *testing.T) {
+		pending := make(chan struct{})
+		defer close(pending)
+		waitCtx, cancel := context.WithCancel(ctx)
+		settled := AllSettled(waitCtx, Go(ctx, value(1, pending)))
+		cancel()
+		if !settled.Await(ctx).ErrIs(context.Canceled) {
+			t.Error("Expected AllSettled to stop when its context is done")
+		}
+	})
+}
+
+func TestAny(t *testing.T) {
+	checkNoLeak(t)
+	ctx := context.Background()
+	firstErr := errors.New("first")
+	secondErr := errors.New("second")
+
+	t.Run("first Ok", func(t *testing.T) {
+		pending := make(chan struct{})
+		defer close(pending)
+		f := Any(ctx, Go(ctx, failure[int](firstErr, closed())), Go(ctx, value(2, closed())), Go(ctx, value(3, pending)))
+		if v := f.Await(ctx).Unwrap(); v != 2 {
+			t.Errorf("Expected 2, got %d", v)
+		}
+	})
+
+	t.Run("all Err", func(t *testing.T) {
+		r := Any(ctx, Go(ctx, failure[int](firstErr, closed())), Go(ctx, failure[int](secondErr, closed()))).Await(ctx)
+		if !r.ErrIs(firstErr) || !r.ErrIs(secondErr) {
+			t.Errorf("Expected joined errors, got %v", r.UnwrapErr())
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		if !Any[int](ctx).Await(ctx).ErrIs(ErrNoFutures) {
+			t.Error("Expected ErrNoFutures")
+		}
+	})
+}
+
+func TestRace(t *testing.T) {
+	checkNoLeak(t)
+	ctx := context.Background()
+	testErr := errors.New("test error")
+
+	pending := make(chan struct{})
+	defer close(pending)
+	r := Race(ctx, Go(ctx, value(1, pending)), Go(ctx, failure[int](testErr, closed()))).Await(ctx)
+	if !r.ErrIs(testErr) {
+		t.Errorf("Expected the first completed Err, got %v", r)
+	}
+
+	if !Race[int](ctx).Await(ctx).ErrIs(ErrNoFutures) {
+		t.Error("Expected ErrNoFutures")
+	}
+}
@@ -1,3 +1,3 @@
 module github.com/azat-dev/go-utils
 
-go 1.22.10
\ No newline at end of file
+go 1.23
\ No newline at end of file
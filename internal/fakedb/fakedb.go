@@ -0,0 +1,171 @@
+// Package fakedb is an in-memory database/sql driver for tests. It does not parse SQL:
+// tests answer statements through hooks and inspect the log of what was executed.
+package fakedb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+)
+
+// ErrNotSupported is returned for prepared statements, which the fake does not implement.
+var ErrNotSupported = errors.New("fakedb: not supported")
+
+// Rows is the answer to a query.
+type Rows struct {
+	Columns []string
+	Values  [][]driver.Value
+}
+
+// DB is a fake database. Set the hooks before opening it; nil hooks succeed with empty results.
+type DB struct {
+	// Query answers QueryContext calls.
+	Query func(query string, args []driver.Value) (Rows, error)
+	// Exec answers ExecContext calls.
+	Exec func(query string, args []driver.Value) error
+	// Begin, Commit and Rollback can fail the transaction calls.
+	Begin    func(opts driver.TxOptions) error
+	Commit   func() error
+	Rollback func() error
+
+	mu  sync.Mutex
+	log []string
+}
+
+// Open returns a *sql.DB backed by d.
+func (d *DB) Open() *sql.DB {
+	return sql.OpenDB(connector{db: d})
+}
+
+// Log returns the statements executed so far, in order. Queries and execs are logged as their SQL text,
+// and transaction calls as "BEGIN", "COMMIT" and "ROLLBACK".
+func (d *DB) Log() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]string(nil), d.log...)
+}
+
+func (d *DB) record(entry string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.log = append(d.log, entry)
+}
+
+type connector struct {
+	db *DB
+}
+
+func (c connector) Connect(context.Context) (driver.Conn, error) {
+	return &conn{db: c.db}, nil
+}
+
+func (c connector) Driver() driver.Driver {
+	return fakeDriver{}
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) {
+	return nil, ErrNotSupported
+}
+
+type conn struct {
+	db *DB
+}
+
+func (c *conn) Prepare(string) (driver.Stmt, error) {
+	return nil, ErrNotSupported
+}
+
+func (c *conn) Close() error {
+	return nil
+}
+
+func (c *conn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *conn) BeginTx(_ context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	c.db.record("BEGIN")
+	if c.db.Begin != nil {
+		if err := c.db.Begin(opts); err != nil {
+			return nil, err
+		}
+	}
+	return tx{db: c.db}, nil
+}
+
+func (c *conn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.db.record(query)
+	if c.db.Query == nil {
+		return &rows{}, nil
+	}
+	result, err := c.db.Query(query, values(args))
+	if err != nil {
+		return nil, err
+	}
+	return &rows{columns: result.Columns, values: result.Values}, nil
+}
+
+func (c *conn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.db.record(query)
+	if c.db.Exec != nil {
+		if err := c.db.Exec(query, values(args)); err != nil {
+			return nil, err
+		}
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func values(args []driver.NamedValue) []driver.Value {
+	vs := make([]driver.Value, len(args))
+	for i, arg := range args {
+		vs[i] = arg.Value
+	}
+	return vs
+}
+
+type tx struct {
+	db *DB
+}
+
+func (t tx) Commit() error {
+	t.db.record("COMMIT")
+	if t.db.Commit != nil {
+		return t.db.Commit()
+	}
+	return nil
+}
+
+func (t tx) Rollback() error {
+	t.db.record("ROLLBACK")
+	if t.db.Rollback != nil {
+		return t.db.Rollback()
+	}
+	return nil
+}
+
+type rows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (r *rows) Columns() []string {
+	return r.columns
+}
+
+func (r *rows) Close() error {
+	return nil
+}
+
+func (r *rows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
@@ -0,0 +1,197 @@
+package kinds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Kind classifies an error by what went wrong from the caller's point of view,
+// independently of where the error came from.
+type Kind int
+
+const (
+	// Unknown is the kind of errors that don't declare one.
+	Unknown Kind = iota
+	// InvalidArgument means the request itself is wrong, whatever the state of the system.
+	InvalidArgument
+	// NotFound means a requested entity does not exist.
+	NotFound
+	// AlreadyExists means an entity the request tried to create exists already.
+	AlreadyExists
+	// Conflict means the request conflicts with the current state, for example a concurrent update.
+	Conflict
+	// FailedPrecondition means the system is not in the state the operation requires.
+	FailedPrecondition
+	// Unauthenticated means the caller could not be identified.
+	Unauthenticated
+	// PermissionDenied means the caller is identified but not allowed to do this.
+	PermissionDenied
+	// ResourceExhausted means a quota or rate limit was hit.
+	ResourceExhausted
+	// Canceled means the caller gave up on the operation.
+	Canceled
+	// DeadlineExceeded means the operation did not finish in time.
+	DeadlineExceeded
+	// Unavailable means a dependency is temporarily unreachable; retrying may succeed.
+	Unavailable
+	// Unimplemented means the operation is not supported.
+	Unimplemented
+	// Internal means a bug or broken invariant; details should not reach clients.
+	Internal
+)
+
+var kindNames = [...]string{
+	Unknown:            "unknown",
+	InvalidArgument:    "invalid argument",
+	NotFound:           "not found",
+	AlreadyExists:      "already exists",
+	Conflict:           "conflict",
+	FailedPrecondition: "failed precondition",
+	Unauthenticated:    "unauthenticated",
+	PermissionDenied:   "permission denied",
+	ResourceExhausted:  "resource exhausted",
+	Canceled:           "canceled",
+	DeadlineExceeded:   "deadline exceeded",
+	Unavailable:        "unavailable",
+	Unimplemented:      "unimplemented",
+	Internal:           "internal",
+}
+
+// String returns the kind in lower case words, such as "not found".
+func (k Kind) String() string {
+	if k < 0 || int(k) >= len(kindNames) {
+		return fmt.Sprintf("Kind(%d)", int(k))
+	}
+	return kindNames[k]
+}
+
+// HTTPStatus returns the HTTP status code conventionally used for the kind.
+// Canceled maps to the non-standard 499; Unknown and Internal map to 500.
+func (k Kind) HTTPStatus() int {
+	switch k {
+	case InvalidArgument, FailedPrecondition:
+		return http.StatusBadRequest
+	case NotFound:
+		return http.StatusNotFound
+	case AlreadyExists, Conflict:
+		return http.StatusConflict
+	case Unauthenticated:
+		return http.StatusUnauthorized
+	case PermissionDenied:
+		return http.StatusForbidden
+	case ResourceExhausted:
+		return http.StatusTooManyRequests
+	case Canceled:
+		return 499
+	case DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case Unavailable:
+		return http.StatusServiceUnavailable
+	case Unimplemented:
+		return http.StatusNotImplemented
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// ExitCode returns a process exit code for the kind, following the BSD sysexits.h conventions
+// (for example 64 for usage errors and 75 for temporary failures). Canceled maps to 130, as after SIGINT.
+func (k Kind) ExitCode() int {
+	switch k {
+	case InvalidArgument:
+		return 64 // EX_USAGE
+	case AlreadyExists, Conflict, FailedPrecondition:
+		return 65 // EX_DATAERR
+	case NotFound:
+		return 66 // EX_NOINPUT
+	case Unimplemented:
+		return 69 // EX_UNAVAILABLE
+	case ResourceExhausted, DeadlineExceeded, Unavailable:
+		return 75 // EX_TEMPFAIL
+	case Unauthenticated, PermissionDenied:
+		return 77 // EX_NOPERM
+	case Canceled:
+		return 130
+	default:
+		return 70 // EX_SOFTWARE
+	}
+}
+
+// Classified is implemented by errors that declare their own Kind.
+// KindOf finds it anywhere in the wrap chain.
+type Classified interface {
+	Kind() Kind
+}
+
+// Error is an error carrying a Kind, created by New, Errorf and Wrap.
+type Error struct {
+	kind Kind
+	msg  string
+	err  error
+}
+
+// Error returns the message.
+func (e *Error) Error() string {
+	return e.msg
+}
+
+// Kind returns the kind the error was created with.
+func (e *Error) Kind() Kind {
+	return e.kind
+}
+
+// Unwrap returns the wrapped error, if any.
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// New returns an error of the given kind with a fixed message.
+func New(kind Kind, msg string) error {
+	return &Error{kind: kind, msg: msg, err: nil}
+}
+
+// Errorf returns an error of the given kind with a message formatted as fmt.Errorf does,
+// including wrapping the errors matched by %w.
+func Errorf(kind Kind, format string, args ...any) error {
+	formatted := fmt.Errorf(format, args...)
+	e := &Error{kind: kind, msg: formatted.Error(), err: nil}
+	switch formatted.(type) {
+	case interface{ Unwrap() error }, interface{ Unwrap() []error }:
+		// Keep the fmt error, which unwraps to everything matched by %w.
+		e.err = formatted
+	}
+	return e
+}
+
+// Wrap attaches a kind to err, keeping its message. It returns nil if err is nil.
+func Wrap(kind Kind, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{kind: kind, msg: err.Error(), err: err}
+}
+
+// KindOf returns the kind declared by the first error in err's chain implementing Classified.
+// Without one, context.Canceled and context.DeadlineExceeded give Canceled and DeadlineExceeded,
+// and anything else, including nil, gives Unknown.
+func KindOf(err error) Kind {
+	var classified Classified
+	switch {
+	case err == nil:
+		return Unknown
+	case errors.As(err, &classified):
+		return classified.Kind()
+	case errors.Is(err, context.Canceled):
+		return Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return DeadlineExceeded
+	}
+	return Unknown
+}
+
+// Is reports whether KindOf(err) is kind.
+func Is(err error, kind Kind) bool {
+	return KindOf(err) == kind
+}
@@ -0,0 +1,116 @@
+package kinds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// quotaError declares its kind without using Error.
+type quotaError struct{}
+
+func (quotaError) Error() string { return "quota exceeded" }
+func (quotaError) Kind() Kind    { return ResourceExhausted }
+
+func TestKindOf(t *testing.T) {
+	errBase := errors.New("base")
+	tests := []struct {
+		name string
+		err  error
+		want Kind
+	}{
+		{"nil", nil, Unknown},
+		{"plain error", errBase, Unknown},
+		{"New", New(NotFound, "no user"), NotFound},
+		{"wrapped by fmt", fmt.Errorf("load: %w", New(Conflict, "version mismatch")), Conflict},
+		{"Wrap", Wrap(Unavailable, errBase), Unavailable},
+		{"custom Classified", fmt.Errorf("call: %w", quotaError{}), ResourceExhausted},
+		{"outermost kind wins", Wrap(Internal, New(NotFound, "no user")), Internal},
+		{"joined", errors.Join(errBase, New(PermissionDenied, "denied")), PermissionDenied},
+		{"context canceled", fmt.Errorf("query: %w", context.Canceled), Canceled},
+		{"context deadline", context.DeadlineExceeded, DeadlineExceeded},
+		{"explicit kind over context", Wrap(Unavailable, context.DeadlineExceeded), Unavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := KindOf(tt.err); got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+			if !Is(tt.err, tt.want) {
+				t.Errorf("Expected Is to report %v", tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorf(t *testing.T) {
+	errBase := errors.New("base")
+
+	err := Errorf(NotFound, "user %d: %w", 7, errBase)
+	if err.Error() != "user 7: base" {
+		t.Errorf("Expected message 'user 7: base', got '%s'", err.Error())
+	}
+	if !errors.Is(err, errBase) {
+		t.Error("Expected Errorf to wrap the %w error")
+	}
+
+	other := errors.New("other")
+	err = Errorf(Conflict, "%w and %w", errBase, other)
+	if !errors.Is(err, errBase) || !errors.Is(err, other) {
+		t.Error("Expected Errorf to wrap every %w error")
+	}
+
+	if errors.Unwrap(Errorf(Internal, "plain %d", 1)) != nil {
+		t.Error("Expected Errorf without %w to wrap nothing")
+	}
+}
+
+func TestWrap(t *testing.T) {
+	if Wrap(NotFound, nil) != nil {
+		t.Error("Expected Wrap(nil) to return nil")
+	}
+	errBase := errors.New("base")
+	err := Wrap(NotFound, errBase)
+	if err.Error() != "base" || !errors.Is(err, errBase) {
+		t.Errorf("Expected Wrap to keep message and chain, got '%v'", err)
+	}
+}
+
+func TestKindMappings(t *testing.T) {
+	tests := []struct {
+		kind   Kind
+		name   string
+		status int
+		exit   int
+	}{
+		{Unknown, "unknown", 500, 70},
+		{InvalidArgument, "invalid argument", 400, 64},
+		{NotFound, "not found", 404, 66},
+		{AlreadyExists, "already exists", 409, 65},
+		{Conflict, "conflict", 409, 65},
+		{FailedPrecondition, "failed precondition", 400, 65},
+		{Unauthenticated, "unauthenticated", 401, 77},
+		{PermissionDenied, "permission denied", 403, 77},
+		{ResourceExhausted, "resource exhausted", 429, 75},
+		{Canceled, "canceled", 499, 130},
+		{DeadlineExceeded, "deadline exceeded", 504, 75},
+		{Unavailable, "unavailable", 503, 75},
+		{Unimplemented, "unimplemented", 501, 69},
+		{Internal, "internal", 500, 70},
+		{Kind(99), "Kind(99)", 500, 70},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.kind.String(); got != tt.name {
+				t.Errorf("Expected String %q, got %q", tt.name, got)
+			}
+			if got := tt.kind.HTTPStatus(); got != tt.status {
+				t.Errorf("Expected HTTP status %d, got %d", tt.status, got)
+			}
+			if got := tt.kind.ExitCode(); got != tt.exit {
+				t.Errorf("Expected exit code %d, got %d", tt.exit, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package lazy
+
+import (
+	"sync"
+	"sync/atomic"
+
+	go_utils "github.com/azat-dev/go-utils"
+
+	"github.com/azat-dev/go-utils/optional"
+	"github.com/azat-dev/go-utils/result"
+)
+
+// Lazy is a value computed on first use and cached forever, including when the computation fails.
+// It is safe for concurrent use; the computation runs at most once.
+// Create it with New; a nil or zero Lazy has no computation and can only be peeked, which gives None.
+type Lazy[T any] struct {
+	once   sync.Once
+	done   atomic.Bool
+	f      func() result.Result[T]
+	result result.Result[T]
+}
+
+// New creates a Lazy that computes its value with f on the first call to Get.
+func New[T any](f func() result.Result[T]) *Lazy[T] {
+	return &Lazy[T]{
+		once:   sync.Once{},
+		done:   atomic.Bool{},
+		f:      f,
+		result: result.Result[T]{},
+	}
+}
+
+// Get computes the value on the first call and returns the cached Result on every call.
+// Concurrent callers wait for the first computation to finish.
+// A panic in the computation is recovered and cached as an Err holding a *result.PanicError.
+func (l *Lazy[T]) Get() result.Result[T] {
+	l.once.Do(func() {
+		l.result = compute(l.f)
+		l.f = nil
+		l.done.Store(true)
+	})
+	return l.result
+}
+
+// Peek returns the value if it has already been computed successfully, without triggering the computation.
+// A nil value, such as from a computation returning a zero Result, gives None.
+func (l *Lazy[T]) Peek() optional.Optional[T] {
+	if l == nil || !l.done.Load() {
+		return optional.None[T]()
+	}
+	v, err := l.result.Get()
+	if err != nil {
+		return optional.None[T]()
+	}
+	return optional.NewFromNullable(v)
+}
+
+// ResettableLazy is a value computed on first use whose errors are not cached:
+// after an Err, the next Get runs the computation again. Use it when initialization can fail transiently.
+// It is safe for concurrent use; at most one computation runs at a time.
+// Create it with NewResettable; a nil or zero ResettableLazy has no computation and can only be peeked.
+type ResettableLazy[T any] struct {
+	mu    sync.Mutex
+	f     func() result.Result[T]
+	value atomic.Pointer[T]
+}
+
+// NewResettable creates a ResettableLazy that computes its value with f.
+func NewResettable[T any](f func() result.Result[T]) *ResettableLazy[T] {
+	return &ResettableLazy[T]{
+		mu:    sync.Mutex{},
+		f:     f,
+		value: atomic.Pointer[T]{},
+	}
+}
+
+// Get returns the cached value, or runs the computation if there is none yet.
+// Ok values are cached until Reset; an Err, or an Ok holding nil such as a zero Result, is returned
+// but not cached, so the next call retries.
+// Concurrent callers wait for a running computation instead of starting another.
+// A panic in the computation is recovered into an Err holding a *result.PanicError.
+func (l *ResettableLazy[T]) Get() result.Result[T] {
+	if v := l.value.Load(); v != nil {
+		return result.Ok(*v)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if v := l.value.Load(); v != nil {
+		return result.Ok(*v)
+	}
+	r := compute(l.f)
+	if v, err := r.Get(); err == nil && !go_utils.IsNil(v) {
+		l.value.Store(&v)
+	}
+	return r
+}
+
+// Peek returns the cached value, without triggering the computation.
+func (l *ResettableLazy[T]) Peek() optional.Optional[T] {
+	if l == nil {
+		return optional.None[T]()
+	}
+	return optional.NewFromNullablePointer(l.value.Load())
+}
+
+// Reset forgets the cached value, so the next Get computes it again.
+func (l *ResettableLazy[T]) Reset() {
+	l.value.Store(nil)
+}
+
+// compute calls f and converts a panic into an Err.
+func compute[T any](f func() result.Result[T]) (r result.Result[T]) {
+	defer result.Catch(&r)
+	return f()
+}
@@ -0,0 +1,168 @@
+package lazy
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/azat-dev/go-utils/result"
+)
+
+var errInit = errors.New("init failed")
+
+func TestLazy(t *testing.T) {
+	t.Run("computes once", func(t *testing.T) {
+		var calls atomic.Int32
+		l := New(func() result.Result[int] {
+			calls.Add(1)
+			return result.Ok(42)
+		})
+
+		if l.Peek().IsSome() {
+			t.Error("Expected Peek to return None before Get")
+		}
+		if calls.Load() != 0 {
+			t.Error("Expected Peek not to trigger computation")
+		}
+
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if v := l.Get().Unwrap(); v != 42 {
+					t.Errorf("Expected 42, got %d", v)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if calls.Load() != 1 {
+			t.Errorf("Expected 1 computation, got %d", calls.Load())
+		}
+		if v := l.Peek().Unwrap(); v != 42 {
+			t.Errorf("Expected Peek to return 42, got %d", v)
+		}
+	})
+
+	t.Run("caches Err", func(t *testing.T) {
+		var calls atomic.Int32
+		l := New(func() result.Result[int] {
+			calls.Add(1)
+			return result.Err[int](errInit)
+		})
+		l.Get()
+		if !l.Get().ErrIs(errInit) {
+			t.Error("Expected cached error")
+		}
+		if calls.Load() != 1 {
+			t.Errorf("Expected 1 computation, got %d", calls.Load())
+		}
+		if l.Peek().IsSome() {
+			t.Error("Expected Peek to return None after Err")
+		}
+	})
+
+	t.Run("recovers panic", func(t *testing.T) {
+		l := New(func() result.Result[int] { panic("boom") })
+		if result.ErrAs[*result.PanicError](l.Get()).IsNone() {
+			t.Error("Expected *PanicError")
+		}
+	})
+}
+
+func TestResettableLazy(t *testing.T) {
+	t.Run("retries after Err", func(t *testing.T) {
+		var calls atomic.Int32
+		l := NewResettable(func() result.Result[string] {
+			if calls.Add(1) == 1 {
+				return result.Err[string](errInit)
+			}
+			return result.Ok("ready")
+		})
+
+		if !l.Get().ErrIs(errInit) {
+			t.Error("Expected first Get to fail")
+		}
+		if l.Peek().IsSome() {
+			t.Error("Expected Peek to return None after Err")
+		}
+		if v := l.Get().Unwrap(); v != "ready" {
+			t.Errorf("Expected 'ready', got '%s'", v)
+		}
+		l.Get()
+		if calls.Load() != 2 {
+			t.Errorf("Expected 2 computations, got %d", calls.Load())
+		}
+		if v := l.Peek().Unwrap(); v != "ready" {
+			t.Errorf("Expected Peek to return 'ready', got '%s'", v)
+		}
+	})
+
+	t.Run("Reset recomputes", func(t *testing.T) {
+		var calls atomic.Int32
+		l := NewResettable(func() result.Result[int32] { return result.Ok(calls.Add(1)) })
+		l.Get()
+		l.Reset()
+		if l.Peek().IsSome() {
+			t.Error("Expected Peek to return None after Reset")
+		}
+		if v := l.Get().Unwrap(); v != 2 {
+			t.Errorf("Expected 2, got %d", v)
+		}
+	})
+
+	t.Run("concurrent callers share a computation", func(t *testing.T) {
+		var calls atomic.Int32
+		l := NewResettable(func() result.Result[int] {
+			calls.Add(1)
+			return result.Ok(1)
+		})
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				l.Get()
+			}()
+		}
+		wg.Wait()
+		if calls.Load() != 1 {
+			t.Errorf("Expected 1 computation, got %d", calls.Load())
+		}
+	})
+
+	t.Run("recovers panic", func(t *testing.T) {
+		l := NewResettable(func() result.Result[int] { panic("boom") })
+		if result.ErrAs[*result.PanicError](l.Get()).IsNone() {
+			t.Error("Expected *PanicError")
+		}
+	})
+}
+
+func TestPeekZero(t *testing.T) {
+	var nilLazy *Lazy[*int]
+	var nilResettable *ResettableLazy[*int]
+	var zero Lazy[*int]
+	var zeroResettable ResettableLazy[*int]
+	if nilLazy.Peek().IsSome() || nilResettable.Peek().IsSome() || zero.Peek().IsSome() || zeroResettable.Peek().IsSome() {
+		t.Error("Expected None from nil and zero values")
+	}
+
+	zeroResult := func() result.Result[*int] { return result.Result[*int]{} }
+	l := New(zeroResult)
+	l.Get()
+	if l.Peek().IsSome() {
+		t.Error("Expected None for a computed zero Result")
+	}
+
+	r := NewResettable(zeroResult)
+	r.Get()
+	if r.Peek().IsSome() {
+		t.Error("Expected a zero Result not to be cached")
+	}
+	if v, err := r.Get().Get(); err != nil || v != nil {
+		t.Errorf("Expected the zero Result again, got %v, %v", v, err)
+	}
+}
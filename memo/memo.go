@@ -0,0 +1,221 @@
+package memo
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/azat-dev/go-utils/clock"
+	"github.com/azat-dev/go-utils/optional"
+	"github.com/azat-dev/go-utils/result"
+)
+
+// Config controls how long a Memo keeps loaded values and how many of them.
+type Config struct {
+	// TTL is how long an Ok value stays cached. Zero means until evicted or invalidated.
+	TTL time.Duration
+	// ErrTTL is how long an Err stays cached. Zero means errors are not cached.
+	ErrTTL time.Duration
+	// MaxSize is the maximum number of cached keys; the least recently used one is evicted first.
+	// Zero means no limit.
+	MaxSize int
+	// Clock is used to expire entries. Nil means clock.Real().
+	Clock clock.Clock
+}
+
+// DefaultConfig returns a Config that caches Ok values for a minute, doesn't cache errors and has no size limit.
+func DefaultConfig() Config {
+	return Config{
+		TTL:     time.Minute,
+		ErrTTL:  0,
+		MaxSize: 0,
+		Clock:   nil,
+	}
+}
+
+// Memo caches the Results of a loader by key and makes concurrent callers for the same key
+// share a single call to the loader. It is safe for concurrent use.
+// Create it with New; a nil or zero Memo has no loader and can only be peeked, which gives None.
+type Memo[K comparable, V any] struct {
+	loader func(context.Context, K) result.Result[V]
+	config Config
+	clock  clock.Clock
+
+	mu       sync.Mutex
+	entries  map[K]*list.Element
+	lru      *list.List
+	inFlight map[K]*call[V]
+}
+
+type entry[K comparable, V any] struct {
+	key     K
+	result  result.Result[V]
+	expires optional.Optional[time.Time]
+}
+
+type call[V any] struct {
+	done   chan struct{}
+	result result.Result[V]
+}
+
+// New creates a Memo that loads missing values with loader.
+func New[K comparable, V any](loader func(context.Context, K) result.Result[V], config Config) *Memo[K, V] {
+	clk := config.Clock
+	if clk == nil {
+		clk = clock.Real()
+	}
+	return &Memo[K, V]{
+		loader:   loader,
+		config:   config,
+		clock:    clk,
+		mu:       sync.Mutex{},
+		entries:  make(map[K]*list.Element),
+		lru:      list.New(),
+		inFlight: make(map[K]*call[V]),
+	}
+}
+
+// Get returns the cached Result for key, or loads it.
+// Concurrent calls for a key that is being loaded wait for that load instead of starting another.
+// The loader runs with a context that is not cancelled when the caller's ctx is,
+// so one caller giving up doesn't fail the others; Get itself returns as soon as ctx is done.
+// A panic in the loader is recovered into an Err holding a *result.PanicError.
+func (m *Memo[K, V]) Get(ctx context.Context, key K) result.Result[V] {
+	m.mu.Lock()
+	if r, ok := m.lookupLocked(key).Get(); ok {
+		m.mu.Unlock()
+		return r
+	}
+	c, loading := m.inFlight[key]
+	if !loading {
+		c = &call[V]{done: make(chan struct{}), result: result.Result[V]{}}
+		m.inFlight[key] = c
+		go m.load(context.WithoutCancel(ctx), key, c)
+	}
+	m.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.result
+	case <-ctx.Done():
+		return result.Err[V](context.Cause(ctx))
+	}
+}
+
+// Peek returns the cached Ok value for key without loading it or changing its recency.
+// Expired entries, cached errors and nil values, such as a zero Result returned by the loader, give None.
+func (m *Memo[K, V]) Peek(key K) optional.Optional[V] {
+	if m == nil {
+		return optional.None[V]()
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	el, ok := m.entries[key]
+	if !ok {
+		return optional.None[V]()
+	}
+	e := el.Value.(*entry[K, V])
+	if m.expiredLocked(e) {
+		return optional.None[V]()
+	}
+	v, err := e.result.Get()
+	if err != nil {
+		return optional.None[V]()
+	}
+	return optional.NewFromNullable(v)
+}
+
+// Invalidate removes key from the cache. A load already in progress for key is not cached when it completes.
+func (m *Memo[K, V]) Invalidate(key K) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if el, ok := m.entries[key]; ok {
+		m.removeLocked(el)
+	}
+	delete(m.inFlight, key)
+}
+
+// InvalidateAll removes every key from the cache.
+func (m *Memo[K, V]) InvalidateAll() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	clear(m.entries)
+	m.lru.Init()
+	clear(m.inFlight)
+}
+
+// Len returns the number of cached keys, including expired ones that haven't been removed yet.
+func (m *Memo[K, V]) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.lru.Len()
+}
+
+func (m *Memo[K, V]) load(ctx context.Context, key K, c *call[V]) {
+	c.result = m.callLoader(ctx, key)
+
+	m.mu.Lock()
+	if m.inFlight[key] == c {
+		delete(m.inFlight, key)
+		m.storeLocked(key, c.result)
+	}
+	m.mu.Unlock()
+	close(c.done)
+}
+
+func (m *Memo[K, V]) callLoader(ctx context.Context, key K) (r result.Result[V]) {
+	defer result.Catch(&r)
+	return m.loader(ctx, key)
+}
+
+// lookupLocked returns the cached Result for key and marks it as recently used.
+// Expired entries are removed.
+func (m *Memo[K, V]) lookupLocked(key K) optional.Optional[result.Result[V]] {
+	el, ok := m.entries[key]
+	if !ok {
+		return optional.None[result.Result[V]]()
+	}
+	e := el.Value.(*entry[K, V])
+	if m.expiredLocked(e) {
+		m.removeLocked(el)
+		return optional.None[result.Result[V]]()
+	}
+	m.lru.MoveToFront(el)
+	return optional.Some(e.result)
+}
+
+func (m *Memo[K, V]) storeLocked(key K, r result.Result[V]) {
+	ttl := m.config.TTL
+	if r.IsErr() {
+		if m.config.ErrTTL <= 0 {
+			return
+		}
+		ttl = m.config.ErrTTL
+	}
+	expires := optional.None[time.Time]()
+	if ttl > 0 {
+		expires = optional.Some(m.clock.Now().Add(ttl))
+	}
+
+	e := &entry[K, V]{key: key, result: r, expires: expires}
+	if el, ok := m.entries[key]; ok {
+		el.Value = e
+		m.lru.MoveToFront(el)
+		return
+	}
+	m.entries[key] = m.lru.PushFront(e)
+	if m.config.MaxSize > 0 && m.lru.Len() > m.config.MaxSize {
+		m.removeLocked(m.lru.Back())
+	}
+}
+
+func (m *Memo[K, V]) expiredLocked(e *entry[K, V]) bool {
+	expires, ok := e.expires.Get()
+	return ok && !m.clock.Now().Before(expires)
+}
+
+func (m *Memo[K, V]) removeLocked(el *list.Element) {
+	m.lru.Remove(el)
+	delete(m.entries, el.Value.(*entry[K, V]).key)
+}
@@ -0,0 +1,228 @@
+package memo
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/azat-dev/go-utils/clock"
+	"github.com/azat-dev/go-utils/result"
+)
+
+var errLoad = errors.New("load failed")
+
+type counter struct {
+	calls atomic.Int32
+}
+
+func (c *counter) loader(_ context.Context, key string) result.Result[string] {
+	c.calls.Add(1)
+	if key == "bad" {
+		return result.Err[string](errLoad)
+	}
+	return result.Ok("value:" + key)
+}
+
+func newTestMemo(config Config) (*Memo[string, string], *counter, *clock.Fake) {
+	fake := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	config.Clock = fake
+	c := &counter{}
+	return New(c.loader, config), c, fake
+}
+
+func TestGet(t *testing.T) {
+	ctx := context.Background()
+	m, c, fake := newTestMemo(DefaultConfig())
+
+	if v := m.Get(ctx, "a").Unwrap(); v != "value:a" {
+		t.Errorf("Expected 'value:a', got '%s'", v)
+	}
+	m.Get(ctx, "a")
+	if c.calls.Load() != 1 {
+		t.Errorf("Expected cached value, got %d loader calls", c.calls.Load())
+	}
+
+	fake.Advance(time.Minute)
+	m.Get(ctx, "a")
+	if c.calls.Load() != 2 {
+		t.Errorf("Expected reload after TTL, got %d loader calls", c.calls.Load())
+	}
+}
+
+func TestErrors(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("not cached by default", func(t *testing.T) {
+		m, c, _ := newTestMemo(DefaultConfig())
+		if !m.Get(ctx, "bad").ErrIs(errLoad) {
+			t.Error("Expected loader error")
+		}
+		m.Get(ctx, "bad")
+		if c.calls.Load() != 2 {
+			t.Errorf("Expected errors not to be cached, got %d loader calls", c.calls.Load())
+		}
+	})
+
+	t.Run("cached with ErrTTL", func(t *testing.T) {
+		config := DefaultConfig()
+		config.ErrTTL = time.Second
+		m, c, fake := newTestMemo(config)
+		m.Get(ctx, "bad")
+		m.Get(ctx, "bad")
+		if c.calls.Load() != 1 {
+			t.Errorf("Expected cached error, got %d loader calls", c.calls.Load())
+		}
+		if m.Peek("bad").IsSome() {
+			t.Error("Expected Peek to return None for cached error")
+		}
+		fake.Advance(time.Second)
+		m.Get(ctx, "bad")
+		if c.calls.Load() != 2 {
+			t.Errorf("Expected reload after ErrTTL, got %d loader calls", c.calls.Load())
+		}
+	})
+
+	t.Run("panic becomes Err", func(t *testing.T) {
+		m := New(func(context.Context, string) result.Result[int] { panic("boom") }, DefaultConfig())
+		if result.ErrAs[*result.PanicError](m.Get(ctx, "a")).IsNone() {
+			t.Error("Expected *PanicError")
+		}
+	})
+}
+
+func TestSingleflight(t *testing.T) {
+	ctx := context.Background()
+	var calls atomic.Int32
+	release := make(chan struct{})
+	m := New(func(_ context.Context, key int) result.Result[int] {
+		calls.Add(1)
+		<-release
+		return result.Ok(key * 2)
+	}, DefaultConfig())
+
+	var wg sync.WaitGroup
+	results := make([]int, 10)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = m.Get(ctx, 21).Unwrap()
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Errorf("Expected 1 loader call, got %d", calls.Load())
+	}
+	for _, v := range results {
+		if v != 42 {
+			t.Errorf("Expected 42, got %d", v)
+		}
+	}
+}
+
+func TestCallerCancellation(t *testing.T) {
+	release := make(chan struct{})
+	m := New(func(ctx context.Context, key int) result.Result[int] {
+		select {
+		case <-release:
+			return result.Ok(key)
+		case <-ctx.Done():
+			return result.Err[int](ctx.Err())
+		}
+	}, DefaultConfig())
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if !m.Get(cancelled, 1).ErrIs(context.Canceled) {
+		t.Error("Expected Get to return the caller's cancellation")
+	}
+
+	close(release)
+	if v := m.Get(context.Background(), 1).Unwrap(); v != 1 {
+		t.Errorf("Expected load to survive caller cancellation, got %d", v)
+	}
+}
+
+func TestPeek(t *testing.T) {
+	m, c, fake := newTestMemo(DefaultConfig())
+	if m.Peek("a").IsSome() {
+		t.Error("Expected None before loading")
+	}
+	if c.calls.Load() != 0 {
+		t.Error("Expected Peek not to load")
+	}
+	m.Get(context.Background(), "a")
+	if v := m.Peek("a").Unwrap(); v != "value:a" {
+		t.Errorf("Expected 'value:a', got '%s'", v)
+	}
+	fake.Advance(time.Minute)
+	if m.Peek("a").IsSome() {
+		t.Error("Expected None after TTL")
+	}
+}
+
+func TestPeekZero(t *testing.T) {
+	var nilMemo *Memo[string, *int]
+	if nilMemo.Peek("a").IsSome() {
+		t.Error("Expected None from a nil Memo")
+	}
+	var zero Memo[string, *int]
+	if zero.Peek("a").IsSome() {
+		t.Error("Expected None from the zero Memo")
+	}
+
+	m := New(func(context.Context, string) result.Result[*int] { return result.Result[*int]{} }, DefaultConfig())
+	m.Get(context.Background(), "a")
+	if m.Peek("a").IsSome() {
+		t.Error("Expected None for a cached zero Result")
+	}
+}
+
+func TestInvalidate(t *testing.T) {
+	ctx := context.Background()
+	m, c, _ := newTestMemo(DefaultConfig())
+	m.Get(ctx, "a")
+	m.Get(ctx, "b")
+
+	m.Invalidate("a")
+	if m.Peek("a").IsSome() || m.Peek("b").IsNone() {
+		t.Error("Expected only 'a' to be invalidated")
+	}
+
+	m.InvalidateAll()
+	if m.Len() != 0 {
+		t.Errorf("Expected empty cache, got %d entries", m.Len())
+	}
+	m.Get(ctx, "a")
+	if c.calls.Load() != 3 {
+		t.Errorf("Expected reload after invalidation, got %d loader calls", c.calls.Load())
+	}
+}
+
+func TestLRUEviction(t *testing.T) {
+	ctx := context.Background()
+	config := DefaultConfig()
+	config.MaxSize = 2
+	m, _, _ := newTestMemo(config)
+
+	m.Get(ctx, "a")
+	m.Get(ctx, "b")
+	m.Get(ctx, "a")
+	m.Get(ctx, "c")
+
+	if m.Len() != 2 {
+		t.Errorf("Expected 2 entries, got %d", m.Len())
+	}
+	if m.Peek("b").IsSome() {
+		t.Error("Expected least recently used 'b' to be evicted")
+	}
+	if m.Peek("a").IsNone() || m.Peek("c").IsNone() {
+		t.Error("Expected 'a' and 'c' to stay cached")
+	}
+}
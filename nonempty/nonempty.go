@@ -0,0 +1,159 @@
+package nonempty
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"slices"
+
+	"github.com/azat-dev/go-utils/optional"
+	"github.com/azat-dev/go-utils/result"
+)
+
+// ErrEmpty is returned when decoding an empty JSON array into a NonEmpty.
+var ErrEmpty = errors.New("nonempty: empty array")
+
+// NonEmpty is a slice that always holds at least one element, so operations such as Head and Reduce can't fail.
+// Create it with Of or FromSlice; the zero value is not a valid NonEmpty, and the methods relying on
+// an element panic on it. Decoding JSON into a struct whose NonEmpty field is missing leaves the zero value,
+// since the decoder never sees the field; use IsZero to check for it.
+type NonEmpty[T any] struct {
+	items []T
+}
+
+// errZero is the panic message of the methods that need an element when called on the zero NonEmpty.
+const errZero = "nonempty: zero NonEmpty used; create it with Of or FromSlice"
+
+// Of creates a NonEmpty from a first element and optional further elements.
+func Of[T any](head T, tail ...T) NonEmpty[T] {
+	items := make([]T, 0, len(tail)+1)
+	items = append(items, head)
+	items = append(items, tail...)
+	return NonEmpty[T]{items: items}
+}
+
+// FromSlice returns Some with a NonEmpty holding a copy of s, or None if s is empty.
+func FromSlice[T any](s []T) optional.Optional[NonEmpty[T]] {
+	if len(s) == 0 {
+		return optional.None[NonEmpty[T]]()
+	}
+	return optional.Some(NonEmpty[T]{items: slices.Clone(s)})
+}
+
+// FromJSON decodes a JSON array into a NonEmpty.
+// It returns an Err holding ErrEmpty for an empty array, or the decoding error.
+func FromJSON[T any](data []byte) result.Result[NonEmpty[T]] {
+	var ne NonEmpty[T]
+	if err := json.Unmarshal(data, &ne); err != nil {
+		return result.Err[NonEmpty[T]](err)
+	}
+	return result.Ok(ne)
+}
+
+// Head returns the first element.
+func (ne NonEmpty[T]) Head() T {
+	return ne.valid()[0]
+}
+
+// Tail returns a copy of all elements after the first. It may be empty.
+func (ne NonEmpty[T]) Tail() []T {
+	return slices.Clone(ne.valid()[1:])
+}
+
+// Last returns the last element.
+func (ne NonEmpty[T]) Last() T {
+	items := ne.valid()
+	return items[len(items)-1]
+}
+
+// IsZero reports whether ne is the zero value, which holds no elements.
+func (ne NonEmpty[T]) IsZero() bool {
+	return len(ne.items) == 0
+}
+
+// Len returns the number of elements, which is at least 1.
+func (ne NonEmpty[T]) Len() int {
+	return len(ne.items)
+}
+
+// At returns Some with the element at index i, or None if i is out of range
+// or the element is nil, which Some rejects.
+func (ne NonEmpty[T]) At(i int) optional.Optional[T] {
+	if i < 0 || i >= len(ne.items) {
+		return optional.None[T]()
+	}
+	return optional.NewFromNullable(ne.items[i])
+}
+
+// ToSlice returns a copy of the elements as a plain slice.
+func (ne NonEmpty[T]) ToSlice() []T {
+	return slices.Clone(ne.items)
+}
+
+// Append returns a new NonEmpty with vs added after the existing elements.
+func (ne NonEmpty[T]) Append(vs ...T) NonEmpty[T] {
+	items := make([]T, 0, len(ne.items)+len(vs))
+	items = append(items, ne.items...)
+	items = append(items, vs...)
+	return NonEmpty[T]{items: items}
+}
+
+// Reduce combines all elements from left to right with f, starting from the first element.
+func (ne NonEmpty[T]) Reduce(f func(T, T) T) T {
+	items := ne.valid()
+	acc := items[0]
+	for _, v := range items[1:] {
+		acc = f(acc, v)
+	}
+	return acc
+}
+
+// Map applies a function to every element and returns the results as a NonEmpty.
+func Map[T, U any](ne NonEmpty[T], f func(T) U) NonEmpty[U] {
+	items := make([]U, len(ne.items))
+	for i, v := range ne.items {
+		items[i] = f(v)
+	}
+	return NonEmpty[U]{items: items}
+}
+
+// Max returns the largest element.
+func Max[T cmp.Ordered](ne NonEmpty[T]) T {
+	return slices.Max(ne.valid())
+}
+
+// Min returns the smallest element.
+func Min[T cmp.Ordered](ne NonEmpty[T]) T {
+	return slices.Min(ne.valid())
+}
+
+// MaxFunc returns the largest element according to compare; the first one if several are maximal.
+func MaxFunc[T any](ne NonEmpty[T], compare func(a, b T) int) T {
+	return slices.MaxFunc(ne.valid(), compare)
+}
+
+// valid returns the elements, and panics with errZero if there are none.
+func (ne NonEmpty[T]) valid() []T {
+	if len(ne.items) == 0 {
+		panic(errZero)
+	}
+	return ne.items
+}
+
+// MarshalJSON encodes the elements as a JSON array.
+func (ne NonEmpty[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ne.items)
+}
+
+// UnmarshalJSON decodes a JSON array and returns ErrEmpty if it has no elements or is null.
+func (ne *NonEmpty[T]) UnmarshalJSON(data []byte) error {
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		return ErrEmpty
+	}
+	ne.items = items
+	return nil
+}
@@ -0,0 +1,159 @@
+package nonempty
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestOf(t *testing.T) {
+	ne := Of(1, 2, 3)
+	if ne.Head() != 1 || ne.Last() != 3 || ne.Len() != 3 {
+		t.Errorf("Unexpected NonEmpty %v", ne.ToSlice())
+	}
+	if tail := ne.Tail(); !slices.Equal(tail, []int{2, 3}) {
+		t.Errorf("Expected tail [2 3], got %v", tail)
+	}
+
+	single := Of("only")
+	if single.Head() != "only" || single.Last() != "only" || len(single.Tail()) != 0 {
+		t.Errorf("Unexpected single-element NonEmpty %v", single.ToSlice())
+	}
+}
+
+func TestFromSlice(t *testing.T) {
+	if FromSlice([]int{}).IsSome() {
+		t.Error("Expected None for empty slice")
+	}
+	if FromSlice[int](nil).IsSome() {
+		t.Error("Expected None for nil slice")
+	}
+
+	source := []int{1, 2}
+	ne := FromSlice(source).Unwrap()
+	source[0] = 100
+	if ne.Head() != 1 {
+		t.Error("Expected FromSlice to copy the slice")
+	}
+}
+
+func TestAt(t *testing.T) {
+	ne := Of("a", "b")
+	if v := ne.At(1).Unwrap(); v != "b" {
+		t.Errorf("Expected 'b', got '%s'", v)
+	}
+	if ne.At(2).IsSome() || ne.At(-1).IsSome() {
+		t.Error("Expected None for out-of-range index")
+	}
+	if Of[error](nil).At(0).IsSome() {
+		t.Error("Expected None for a nil element")
+	}
+}
+
+func TestAppend(t *testing.T) {
+	ne := Of(1)
+	appended := ne.Append(2, 3)
+	if !slices.Equal(appended.ToSlice(), []int{1, 2, 3}) {
+		t.Errorf("Expected [1 2 3], got %v", appended.ToSlice())
+	}
+	if ne.Len() != 1 {
+		t.Error("Expected Append not to modify the original")
+	}
+}
+
+func TestReduceAndMax(t *testing.T) {
+	ne := Of(3, 9, 1, 4)
+	if sum := ne.Reduce(func(a, b int) int { return a + b }); sum != 17 {
+		t.Errorf("Expected 17, got %d", sum)
+	}
+	if v := Max(ne); v != 9 {
+		t.Errorf("Expected 9, got %d", v)
+	}
+	if v := Min(ne); v != 1 {
+		t.Errorf("Expected 1, got %d", v)
+	}
+	words := Of("go", "gopher", "g")
+	if v := MaxFunc(words, func(a, b string) int { return cmp.Compare(len(a), len(b)) }); v != "gopher" {
+		t.Errorf("Expected 'gopher', got '%s'", v)
+	}
+}
+
+func TestMap(t *testing.T) {
+	lengths := Map(Of("a", "bb"), func(s string) int { return len(s) })
+	if !slices.Equal(lengths.ToSlice(), []int{1, 2}) {
+		t.Errorf("Expected [1 2], got %v", lengths.ToSlice())
+	}
+}
+
+func TestJSON(t *testing.T) {
+	data, err := json.Marshal(Of(1, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "[1,2]" {
+		t.Errorf("Expected [1,2], got %s", data)
+	}
+
+	ne := FromJSON[int](data).Unwrap()
+	if !slices.Equal(ne.ToSlice(), []int{1, 2}) {
+		t.Errorf("Expected [1 2], got %v", ne.ToSlice())
+	}
+
+	for _, input := range []string{"[]", "null"} {
+		if r := FromJSON[int]([]byte(input)); !r.ErrIs(ErrEmpty) {
+			t.Errorf("Expected ErrEmpty for %s, got %v", input, r)
+		}
+	}
+	if r := FromJSON[int]([]byte(`["x"]`)); r.IsOk() || r.ErrIs(ErrEmpty) {
+		t.Errorf("Expected decoding error, got %v", r)
+	}
+
+	var payload struct {
+		Tags NonEmpty[string] `json:"tags"`
+	}
+	err = json.Unmarshal([]byte(`{"tags":[]}`), &payload)
+	if !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty inside struct, got %v", err)
+	}
+}
+
+func TestZero(t *testing.T) {
+	var payload struct {
+		Tags NonEmpty[string] `json:"tags"`
+	}
+	if err := json.Unmarshal([]byte(`{}`), &payload); err != nil {
+		t.Fatal(err)
+	}
+	if !payload.Tags.IsZero() {
+		t.Error("Expected a missing field to leave the zero value")
+	}
+	if Of(1).IsZero() {
+		t.Error("Expected Of to give a non-zero value")
+	}
+
+	tests := []struct {
+		name string
+		call func(NonEmpty[string])
+	}{
+		{"Head", func(ne NonEmpty[string]) { ne.Head() }},
+		{"Last", func(ne NonEmpty[string]) { ne.Last() }},
+		{"Tail", func(ne NonEmpty[string]) { ne.Tail() }},
+		{"Reduce", func(ne NonEmpty[string]) { ne.Reduce(func(a, b string) string { return a + b }) }},
+		{"Max", func(ne NonEmpty[string]) { Max(ne) }},
+		{"Min", func(ne NonEmpty[string]) { Min(ne) }},
+		{"MaxFunc", func(ne NonEmpty[string]) { MaxFunc(ne, strings.Compare) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != errZero {
+					t.Errorf("Expected panic %q, got %v", errZero, r)
+				}
+			}()
+			tt.call(payload.Tags)
+		})
+	}
+}
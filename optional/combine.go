@@ -0,0 +1,80 @@
+package optional
+
+import "github.com/azat-dev/go-utils/tuple"
+
+// Map2 combines the values of two Optionals with f if both are present.
+// Otherwise, it returns None.
+func Map2[A, B, R any](a Optional[A], b Optional[B], f func(A, B) R) Optional[R] {
+	if a.present && b.present {
+		return Some(f(a.value, b.value))
+	}
+	return None[R]()
+}
+
+// Map3 combines the values of three Optionals with f if all of them are present.
+// Otherwise, it returns None.
+func Map3[A, B, C, R any](a Optional[A], b Optional[B], c Optional[C], f func(A, B, C) R) Optional[R] {
+	if a.present && b.present && c.present {
+		return Some(f(a.value, b.value, c.value))
+	}
+	return None[R]()
+}
+
+// Map4 combines the values of four Optionals with f if all of them are present.
+// Otherwise, it returns None.
+func Map4[A, B, C, D, R any](
+	a Optional[A],
+	b Optional[B],
+	c Optional[C],
+	d Optional[D],
+	f func(A, B, C, D) R,
+) Optional[R] {
+	if a.present && b.present && c.present && d.present {
+		return Some(f(a.value, b.value, c.value, d.value))
+	}
+	return None[R]()
+}
+
+// Map5 combines the values of five Optionals with f if all of them are present.
+// Otherwise, it returns None.
+func Map5[A, B, C, D, E, R any](
+	a Optional[A],
+	b Optional[B],
+	c Optional[C],
+	d Optional[D],
+	e Optional[E],
+	f func(A, B, C, D, E) R,
+) Optional[R] {
+	if a.present && b.present && c.present && d.present && e.present {
+		return Some(f(a.value, b.value, c.value, d.value, e.value))
+	}
+	return None[R]()
+}
+
+// Zip combines two Optionals into an Optional of a Pair, or returns None if either is None.
+func Zip[A, B any](a Optional[A], b Optional[B]) Optional[tuple.Pair[A, B]] {
+	return Map2(a, b, tuple.NewPair[A, B])
+}
+
+// Zip3 combines three Optionals into an Optional of a Triple, or returns None if any is None.
+func Zip3[A, B, C any](a Optional[A], b Optional[B], c Optional[C]) Optional[tuple.Triple[A, B, C]] {
+	return Map3(a, b, c, tuple.NewTriple[A, B, C])
+}
+
+// Unzip splits an Optional of a Pair into two Optionals.
+// If o is None, both returned Optionals are None. A nil member gives None, as with NewFromNullable.
+func Unzip[A, B any](o Optional[tuple.Pair[A, B]]) (Optional[A], Optional[B]) {
+	if !o.present {
+		return None[A](), None[B]()
+	}
+	return NewFromNullable(o.value.First), NewFromNullable(o.value.Second)
+}
+
+// Unzip3 splits an Optional of a Triple into three Optionals.
+// If o is None, all returned Optionals are None. A nil member gives None, as with NewFromNullable.
+func Unzip3[A, B, C any](o Optional[tuple.Triple[A, B, C]]) (Optional[A], Optional[B], Optional[C]) {
+	if !o.present {
+		return None[A](), None[B](), None[C]()
+	}
+	return NewFromNullable(o.value.First), NewFromNullable(o.value.Second), NewFromNullable(o.value.Third)
+}
@@ -0,0 +1,64 @@
+package optional
+
+import (
+	"testing"
+
+	"github.com/azat-dev/go-utils/tuple"
+)
+
+func TestMap2(t *testing.T) {
+	concat := func(a string, b int) string { return a + string(rune('0'+b)) }
+
+	if value := Map2(Some("a"), Some(1), concat).Unwrap(); value != "a1" {
+		t.Errorf("Expected 'a1', got '%s'", value)
+	}
+	if Map2(Some("a"), None[int](), concat).IsSome() {
+		t.Error("Expected None when any input is None")
+	}
+}
+
+func TestMap5(t *testing.T) {
+	sum := func(a, b, c, d, e int) int { return a + b + c + d + e }
+
+	if value := Map5(Some(1), Some(2), Some(3), Some(4), Some(5), sum).Unwrap(); value != 15 {
+		t.Errorf("Expected 15, got %d", value)
+	}
+	if Map5(Some(1), Some(2), None[int](), Some(4), Some(5), sum).IsSome() {
+		t.Error("Expected None when any input is None")
+	}
+	if value := Map3(Some(1), Some(2), Some(3), func(a, b, c int) int { return a + b + c }).Unwrap(); value != 6 {
+		t.Errorf("Expected 6, got %d", value)
+	}
+	if Map4(Some(1), Some(2), Some(3), None[int](), func(a, b, c, d int) int { return a + b + c + d }).IsSome() {
+		t.Error("Expected None when any input is None")
+	}
+}
+
+func TestZip(t *testing.T) {
+	if pair := Zip(Some("a"), Some(1)).Unwrap(); pair != tuple.NewPair("a", 1) {
+		t.Errorf("Unexpected pair %+v", pair)
+	}
+	if triple := Zip3(Some("a"), Some(1), Some(true)).Unwrap(); triple != tuple.NewTriple("a", 1, true) {
+		t.Errorf("Unexpected triple %+v", triple)
+	}
+	if Zip(None[string](), Some(1)).IsSome() {
+		t.Error("Expected None when any input is None")
+	}
+}
+
+func TestUnzip(t *testing.T) {
+	a, b := Unzip(Some(tuple.NewPair("a", 1)))
+	if a.Unwrap() != "a" || b.Unwrap() != 1 {
+		t.Errorf("Unexpected values %v %v", a, b)
+	}
+
+	x, y, z := Unzip3(None[tuple.Triple[string, int, bool]]())
+	if x.IsSome() || y.IsSome() || z.IsSome() {
+		t.Error("Expected all None for None input")
+	}
+
+	p, n := Unzip(Some(tuple.Pair[*int, int]{First: nil, Second: 1}))
+	if p.IsSome() || n.Unwrap() != 1 {
+		t.Errorf("Expected None for the nil member and Some(1), got %v %v", p, n)
+	}
+}
@@ -0,0 +1,26 @@
+package optional
+
+import (
+	"database/sql"
+	"database/sql/driver"
+)
+
+// Scan implements sql.Scanner, so Optional can be scanned from a nullable column: NULL gives None,
+// and any other value is converted to T as Rows.Scan would convert it and gives Some.
+func (o *Optional[T]) Scan(src any) error {
+	var n sql.Null[T]
+	if err := n.Scan(src); err != nil {
+		return err
+	}
+	*o = Optional[T]{value: n.V, present: n.Valid}
+	return nil
+}
+
+// Value implements driver.Valuer, so Optional can be passed as a query argument: None is written as NULL,
+// and Some is converted as the value itself would be, so Some(42) is written as int64(42).
+func (o Optional[T]) Value() (driver.Value, error) {
+	if !o.present {
+		return nil, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(o.value)
+}
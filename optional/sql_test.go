@@ -0,0 +1,96 @@
+package optional
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/azat-dev/go-utils/internal/fakedb"
+)
+
+func TestScan(t *testing.T) {
+	t.Run("NULL gives None", func(t *testing.T) {
+		o := Some("stale")
+		if err := o.Scan(nil); err != nil {
+			t.Fatal(err)
+		}
+		if o.IsSome() {
+			t.Error("Expected None after scanning NULL")
+		}
+	})
+
+	t.Run("value is converted", func(t *testing.T) {
+		var o Optional[int]
+		if err := o.Scan(int64(42)); err != nil {
+			t.Fatal(err)
+		}
+		if value, ok := o.Get(); !ok || value != 42 {
+			t.Errorf("Expected Some(42), got %v, %v", value, ok)
+		}
+
+		var s Optional[string]
+		if err := s.Scan([]byte("hello")); err != nil {
+			t.Fatal(err)
+		}
+		if value := s.UnwrapOr(""); value != "hello" {
+			t.Errorf("Expected Some(hello), got '%s'", value)
+		}
+	})
+
+	t.Run("conversion error", func(t *testing.T) {
+		var o Optional[int]
+		if err := o.Scan("not a number"); err == nil {
+			t.Error("Expected an error converting a string to int")
+		}
+	})
+}
+
+func TestValue(t *testing.T) {
+	tests := []struct {
+		name string
+		o    driver.Valuer
+		want driver.Value
+	}{
+		{"None", None[string](), nil},
+		{"Some", Some("hello"), "hello"},
+		{"Some int64", Some(int64(7)), int64(7)},
+		{"Some int", Some(7), int64(7)},
+		{"Some Valuer", Some(Some("inner")), "inner"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.o.Value()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDatabaseRoundTrip(t *testing.T) {
+	// echo returns the query arguments as the only row, so every value goes through the driver and back.
+	fake := &fakedb.DB{Query: func(_ string, args []driver.Value) (fakedb.Rows, error) {
+		return fakedb.Rows{Columns: []string{"a", "b", "c"}, Values: [][]driver.Value{args}}, nil
+	}}
+	db := fake.Open()
+	defer db.Close()
+
+	var number Optional[int]
+	var text, missing Optional[string]
+	row := db.QueryRowContext(context.Background(), "SELECT ?, ?, ?", Some(42), Some("hello"), None[string]())
+	if err := row.Scan(&number, &text, &missing); err != nil {
+		t.Fatal(err)
+	}
+	if value, ok := number.Get(); !ok || value != 42 {
+		t.Errorf("Expected Some(42), got %v, %v", value, ok)
+	}
+	if value, ok := text.Get(); !ok || value != "hello" {
+		t.Errorf("Expected Some(hello), got '%s', %v", value, ok)
+	}
+	if missing.IsSome() {
+		t.Error("Expected None")
+	}
+}
@@ -0,0 +1,159 @@
+package refine
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"slices"
+	"unicode/utf8"
+)
+
+// errNilFunc is returned by the Check method of a nil Func, such as the zero value Refined uses for P.
+var errNilFunc = errors.New("refine: nil Func used as a predicate; declare a named predicate type instead")
+
+// Func adapts a function to the Predicate interface.
+// The predicate builders below return Func values, to be called from a predicate type's Check method.
+// A nil Func rejects every value, so Refined[T, Func[T]] never holds a checked value.
+type Func[T any] func(T) error
+
+// Check calls the function.
+func (f Func[T]) Check(v T) error {
+	if f == nil {
+		return errNilFunc
+	}
+	return f(v)
+}
+
+// InRange accepts values between lo and hi, inclusive.
+func InRange[T cmp.Ordered](lo, hi T) Func[T] {
+	return func(v T) error {
+		if v < lo || v > hi {
+			return fmt.Errorf("%v is not in range [%v, %v]", v, lo, hi)
+		}
+		return nil
+	}
+}
+
+// MatchRegexp accepts strings matching re.
+func MatchRegexp(re *regexp.Regexp) Func[string] {
+	return func(v string) error {
+		if !re.MatchString(v) {
+			return fmt.Errorf("%q does not match %s", v, re)
+		}
+		return nil
+	}
+}
+
+// LenBetween accepts strings whose length in runes is between lo and hi, inclusive.
+func LenBetween(lo, hi int) Func[string] {
+	return func(v string) error {
+		if n := utf8.RuneCountInString(v); n < lo || n > hi {
+			return fmt.Errorf("length %d is not in range [%d, %d]", n, lo, hi)
+		}
+		return nil
+	}
+}
+
+// OneOf accepts only the listed values.
+func OneOf[T comparable](values ...T) Func[T] {
+	return func(v T) error {
+		if !slices.Contains(values, v) {
+			return fmt.Errorf("%v is not one of %v", v, values)
+		}
+		return nil
+	}
+}
+
+// And accepts values that satisfy every predicate, and returns the first failure otherwise.
+func And[T any](predicates ...Predicate[T]) Func[T] {
+	return func(v T) error {
+		for _, p := range predicates {
+			if err := p.Check(v); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// Or accepts values that satisfy at least one predicate, and returns all failures joined otherwise.
+// Without predicates it rejects every value, as no predicate is satisfied.
+func Or[T any](predicates ...Predicate[T]) Func[T] {
+	return func(v T) error {
+		if len(predicates) == 0 {
+			return fmt.Errorf("%v satisfies no predicate of an empty Or", v)
+		}
+		errs := make([]error, 0, len(predicates))
+		for _, p := range predicates {
+			err := p.Check(v)
+			if err == nil {
+				return nil
+			}
+			errs = append(errs, err)
+		}
+		return errors.Join(errs...)
+	}
+}
+
+// IsNonEmpty accepts strings with at least one character.
+type IsNonEmpty struct{}
+
+// Check implements Predicate.
+func (IsNonEmpty) Check(v string) error {
+	if v == "" {
+		return errors.New("must not be empty")
+	}
+	return nil
+}
+
+// Number is the set of types accepted by IsPositive.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// IsPositive accepts numbers greater than zero.
+type IsPositive[T Number] struct{}
+
+// Check implements Predicate.
+func (IsPositive[T]) Check(v T) error {
+	if v <= 0 {
+		return fmt.Errorf("%v is not positive", v)
+	}
+	return nil
+}
+
+// IsEmail accepts a bare email address such as "user@example.com", without a display name.
+type IsEmail struct{}
+
+// Check implements Predicate.
+func (IsEmail) Check(v string) error {
+	addr, err := mail.ParseAddress(v)
+	if err != nil || addr.Address != v {
+		return fmt.Errorf("%q is not an email address", v)
+	}
+	return nil
+}
+
+// IsPort accepts TCP and UDP port numbers from 1 to 65535.
+type IsPort struct{}
+
+// Check implements Predicate.
+func (IsPort) Check(v int) error {
+	return InRange(1, 65535).Check(v)
+}
+
+// NonEmptyString is a string with at least one character.
+type NonEmptyString = Refined[string, IsNonEmpty]
+
+// PositiveInt is an int greater than zero.
+type PositiveInt = Refined[int, IsPositive[int]]
+
+// Email is a bare email address.
+type Email = Refined[string, IsEmail]
+
+// Port is a TCP or UDP port number.
+type Port = Refined[int, IsPort]
@@ -0,0 +1,58 @@
+package refine
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestPredicates(t *testing.T) {
+	hex := regexp.MustCompile(`^[0-9a-f]+$`)
+	tests := []struct {
+		name  string
+		check func() error
+		valid bool
+	}{
+		{"InRange inside", func() error { return InRange(1, 10).Check(10) }, true},
+		{"InRange outside", func() error { return InRange(1.0, 2.0).Check(2.5) }, false},
+		{"MatchRegexp match", func() error { return MatchRegexp(hex).Check("ff") }, true},
+		{"MatchRegexp mismatch", func() error { return MatchRegexp(hex).Check("zz") }, false},
+		{"LenBetween runes", func() error { return LenBetween(2, 2).Check("привет"[:4]) }, true},
+		{"LenBetween too long", func() error { return LenBetween(0, 2).Check("abc") }, false},
+		{"OneOf member", func() error { return OneOf("dev", "prod").Check("prod") }, true},
+		{"OneOf other", func() error { return OneOf("dev", "prod").Check("test") }, false},
+		{"And all", func() error { return And[int](InRange(0, 10), OneOf(2, 4)).Check(4) }, true},
+		{"And one fails", func() error { return And[int](InRange(0, 10), OneOf(2, 4)).Check(5) }, false},
+		{"Or one", func() error { return Or[int](OneOf(1), OneOf(2)).Check(2) }, true},
+		{"Or none", func() error { return Or[int](OneOf(1), OneOf(2)).Check(3) }, false},
+		{"Or without predicates", func() error { return Or[int]().Check(1) }, false},
+		{"And without predicates", func() error { return And[int]().Check(1) }, true},
+		{"IsNonEmpty", func() error { return IsNonEmpty{}.Check("x") }, true},
+		{"IsNonEmpty empty", func() error { return IsNonEmpty{}.Check("") }, false},
+		{"IsPositive", func() error { return IsPositive[float64]{}.Check(0.5) }, true},
+		{"IsPositive zero", func() error { return IsPositive[int]{}.Check(0) }, false},
+		{"IsEmail", func() error { return IsEmail{}.Check("a@example.com") }, true},
+		{"IsEmail display name", func() error { return IsEmail{}.Check("A <a@example.com>") }, false},
+		{"IsEmail garbage", func() error { return IsEmail{}.Check("not an email") }, false},
+		{"IsPort", func() error { return IsPort{}.Check(65535) }, true},
+		{"IsPort zero", func() error { return IsPort{}.Check(0) }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.check()
+			if tt.valid && err != nil {
+				t.Errorf("Expected valid, got %v", err)
+			}
+			if !tt.valid && err == nil {
+				t.Error("Expected invalid")
+			}
+		})
+	}
+}
+
+func TestOrJoinsErrors(t *testing.T) {
+	err := Or[int](OneOf(1), InRange(5, 6)).Check(3)
+	expectedMsg := "3 is not one of [1]\n3 is not in range [5, 6]"
+	if err == nil || err.Error() != expectedMsg {
+		t.Errorf("Expected message '%s', got '%v'", expectedMsg, err)
+	}
+}
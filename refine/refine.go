@@ -0,0 +1,110 @@
+package refine
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/azat-dev/go-utils/result"
+)
+
+// ErrInvalid is wrapped by every error returned when a value doesn't satisfy its predicate.
+var ErrInvalid = errors.New("refine: invalid value")
+
+// Predicate checks a value. Refined uses the zero value of the predicate type,
+// so only named types whose zero value checks can be used as P, and a predicate with parameters
+// encodes them in its Check method. Func can't be used as P, since its zero value has no function:
+//
+//	type IsPercent struct{}
+//
+//	func (IsPercent) Check(v int) error { return refine.InRange(0, 100).Check(v) }
+type Predicate[T any] interface {
+	Check(v T) error
+}
+
+// Refined is a value of type T that is known to satisfy the predicate P.
+// The only way to get a valid Refined is through New, MustNew or decoding, which all run the check.
+// The zero value holds the zero value of T, which has not been checked.
+type Refined[T any, P Predicate[T]] struct {
+	value T
+}
+
+// New checks v against P and returns Ok with the Refined value,
+// or an Err wrapping ErrInvalid and the predicate's error.
+func New[T any, P Predicate[T]](v T) result.Result[Refined[T, P]] {
+	var p P
+	if err := p.Check(v); err != nil {
+		return result.Err[Refined[T, P]](fmt.Errorf("%w: %w", ErrInvalid, err))
+	}
+	return result.Ok(Refined[T, P]{value: v})
+}
+
+// MustNew is like New but panics if v doesn't satisfy P.
+// Convenient for constants and test code.
+func MustNew[T any, P Predicate[T]](v T) Refined[T, P] {
+	return New[T, P](v).MustGet()
+}
+
+// Value returns the underlying value.
+func (r Refined[T, P]) Value() T {
+	return r.value
+}
+
+// String returns the underlying value formatted with %v.
+func (r Refined[T, P]) String() string {
+	return fmt.Sprint(r.value)
+}
+
+// MarshalJSON encodes the underlying value.
+func (r Refined[T, P]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.value)
+}
+
+// UnmarshalJSON decodes the underlying value and checks it against P.
+func (r *Refined[T, P]) UnmarshalJSON(data []byte) error {
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	return r.set(v)
+}
+
+// MarshalText encodes the underlying value as text.
+// Types implementing encoding.TextMarshaler use it, strings are used as is, and other values use their JSON form.
+func (r Refined[T, P]) MarshalText() ([]byte, error) {
+	if m, ok := any(r.value).(encoding.TextMarshaler); ok {
+		return m.MarshalText()
+	}
+	if rv := reflect.ValueOf(r.value); rv.Kind() == reflect.String {
+		return []byte(rv.String()), nil
+	}
+	return json.Marshal(r.value)
+}
+
+// UnmarshalText decodes the underlying value from text and checks it against P.
+// Types implementing encoding.TextUnmarshaler use it, strings are used as is, and other values are decoded as JSON,
+// which covers numbers and booleans.
+func (r *Refined[T, P]) UnmarshalText(text []byte) error {
+	var v T
+	if target, ok := any(&v).(encoding.TextUnmarshaler); ok {
+		if err := target.UnmarshalText(text); err != nil {
+			return err
+		}
+	} else if rv := reflect.ValueOf(&v).Elem(); rv.Kind() == reflect.String {
+		rv.SetString(string(text))
+	} else if err := json.Unmarshal(text, &v); err != nil {
+		return err
+	}
+	return r.set(v)
+}
+
+func (r *Refined[T, P]) set(v T) error {
+	refined, err := New[T, P](v).Get()
+	if err != nil {
+		return err
+	}
+	*r = refined
+	return nil
+}
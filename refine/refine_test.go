@@ -0,0 +1,158 @@
+package refine
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/azat-dev/go-utils/result"
+)
+
+type username string
+
+type isUsername struct{}
+
+func (isUsername) Check(v username) error {
+	return LenBetween(3, 8).Check(string(v))
+}
+
+func TestNew(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		email := New[string, IsEmail]("user@example.com").Unwrap()
+		if email.Value() != "user@example.com" {
+			t.Errorf("Expected 'user@example.com', got '%s'", email.Value())
+		}
+		if email.String() != "user@example.com" {
+			t.Errorf("Expected String to return the value, got '%s'", email.String())
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		r := New[int, IsPort](70000)
+		if !r.ErrIs(ErrInvalid) {
+			t.Errorf("Expected ErrInvalid, got %v", r)
+		}
+		expectedMsg := "refine: invalid value: 70000 is not in range [1, 65535]"
+		if r.UnwrapErr().Error() != expectedMsg {
+			t.Errorf("Expected message '%s', got '%s'", expectedMsg, r.UnwrapErr().Error())
+		}
+	})
+
+	t.Run("Func as predicate type", func(t *testing.T) {
+		r := New[int, Func[int]](1)
+		if !r.ErrIs(ErrInvalid) || !r.ErrIs(errNilFunc) {
+			t.Errorf("Expected ErrInvalid wrapping errNilFunc, got %v", r)
+		}
+	})
+}
+
+func TestMustNew(t *testing.T) {
+	if port := MustNew[int, IsPort](8080); port.Value() != 8080 {
+		t.Errorf("Expected 8080, got %d", port.Value())
+	}
+
+	defer func() {
+		r := recover()
+		err, ok := r.(error)
+		if !ok || !errors.Is(err, ErrInvalid) {
+			t.Errorf("Expected panic wrapping ErrInvalid, got %v", r)
+		}
+	}()
+	MustNew[string, IsNonEmpty]("")
+}
+
+func TestJSON(t *testing.T) {
+	type config struct {
+		Port  Port  `json:"port"`
+		Admin Email `json:"admin"`
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		var c config
+		if err := json.Unmarshal([]byte(`{"port":8080,"admin":"root@example.com"}`), &c); err != nil {
+			t.Fatal(err)
+		}
+		if c.Port.Value() != 8080 || c.Admin.Value() != "root@example.com" {
+			t.Errorf("Unexpected config %+v", c)
+		}
+		data, err := json.Marshal(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != `{"port":8080,"admin":"root@example.com"}` {
+			t.Errorf("Unexpected JSON %s", data)
+		}
+	})
+
+	t.Run("validation runs on decode", func(t *testing.T) {
+		var c config
+		err := json.Unmarshal([]byte(`{"port":0,"admin":"root@example.com"}`), &c)
+		if !errors.Is(err, ErrInvalid) {
+			t.Errorf("Expected ErrInvalid, got %v", err)
+		}
+	})
+
+	t.Run("type mismatch", func(t *testing.T) {
+		var p Port
+		if err := json.Unmarshal([]byte(`"80"`), &p); err == nil || errors.Is(err, ErrInvalid) {
+			t.Errorf("Expected decoding error, got %v", err)
+		}
+	})
+}
+
+func TestText(t *testing.T) {
+	t.Run("string", func(t *testing.T) {
+		var name Refined[username, isUsername]
+		if err := name.UnmarshalText([]byte("gopher")); err != nil {
+			t.Fatal(err)
+		}
+		if name.Value() != "gopher" {
+			t.Errorf("Expected 'gopher', got '%s'", name.Value())
+		}
+		text, err := name.MarshalText()
+		if err != nil || string(text) != "gopher" {
+			t.Errorf("Expected 'gopher', got '%s' (%v)", text, err)
+		}
+		if err := name.UnmarshalText([]byte("go")); !errors.Is(err, ErrInvalid) {
+			t.Errorf("Expected ErrInvalid, got %v", err)
+		}
+	})
+
+	t.Run("number", func(t *testing.T) {
+		var port Port
+		if err := port.UnmarshalText([]byte("443")); err != nil {
+			t.Fatal(err)
+		}
+		text, err := port.MarshalText()
+		if err != nil || string(text) != "443" {
+			t.Errorf("Expected '443', got '%s' (%v)", text, err)
+		}
+		if err := port.UnmarshalText([]byte("http")); err == nil {
+			t.Error("Expected error for non-numeric text")
+		}
+	})
+
+	t.Run("map keys", func(t *testing.T) {
+		var m map[NonEmptyString]int
+		if err := json.Unmarshal([]byte(`{"a":1}`), &m); err != nil {
+			t.Fatal(err)
+		}
+		if m[MustNew[string, IsNonEmpty]("a")] != 1 {
+			t.Errorf("Unexpected map %v", m)
+		}
+		if err := json.Unmarshal([]byte(`{"":1}`), &m); !errors.Is(err, ErrInvalid) {
+			t.Errorf("Expected ErrInvalid for empty key, got %v", err)
+		}
+	})
+}
+
+func TestWithResult(t *testing.T) {
+	ports := result.Map2(
+		New[int, IsPort](80),
+		New[int, IsPort](443),
+		func(http, https Port) []int { return []int{http.Value(), https.Value()} },
+	)
+	if v := ports.Unwrap(); v[0] != 80 || v[1] != 443 {
+		t.Errorf("Unexpected ports %v", v)
+	}
+}
@@ -0,0 +1,117 @@
+package result
+
+import (
+	go_utils "github.com/azat-dev/go-utils"
+
+	"github.com/azat-dev/go-utils/tuple"
+)
+
+// Map2 combines the values of two Results with f if both are Ok.
+// Otherwise, it returns the first Err, checked from left to right.
+func Map2[A, B, R any](a Result[A], b Result[B], f func(A, B) R) Result[R] {
+	if err := firstErr(a.err, b.err); err != nil {
+		return Err[R](err)
+	}
+	return Ok(f(a.value, b.value))
+}
+
+// Map3 combines the values of three Results with f if all of them are Ok.
+// Otherwise, it returns the first Err, checked from left to right.
+func Map3[A, B, C, R any](a Result[A], b Result[B], c Result[C], f func(A, B, C) R) Result[R] {
+	if err := firstErr(a.err, b.err, c.err); err != nil {
+		return Err[R](err)
+	}
+	return Ok(f(a.value, b.value, c.value))
+}
+
+// Map4 combines the values of four Results with f if all of them are Ok.
+// Otherwise, it returns the first Err, checked from left to right.
+func Map4[A, B, C, D, R any](
+	a Result[A],
+	b Result[B],
+	c Result[C],
+	d Result[D],
+	f func(A, B, C, D) R,
+) Result[R] {
+	if err := firstErr(a.err, b.err, c.err, d.err); err != nil {
+		return Err[R](err)
+	}
+	return Ok(f(a.value, b.value, c.value, d.value))
+}
+
+// Map5 combines the values of five Results with f if all of them are Ok.
+// Otherwise, it returns the first Err, checked from left to right.
+func Map5[A, B, C, D, E, R any](
+	a Result[A],
+	b Result[B],
+	c Result[C],
+	d Result[D],
+	e Result[E],
+	f func(A, B, C, D, E) R,
+) Result[R] {
+	if err := firstErr(a.err, b.err, c.err, d.err, e.err); err != nil {
+		return Err[R](err)
+	}
+	return Ok(f(a.value, b.value, c.value, d.value, e.value))
+}
+
+// Zip combines two Results into a Result of a Pair, or returns the first Err.
+func Zip[A, B any](a Result[A], b Result[B]) Result[tuple.Pair[A, B]] {
+	return Map2(a, b, tuple.NewPair[A, B])
+}
+
+// Zip3 combines three Results into a Result of a Triple, or returns the first Err.
+func Zip3[A, B, C any](a Result[A], b Result[B], c Result[C]) Result[tuple.Triple[A, B, C]] {
+	return Map3(a, b, c, tuple.NewTriple[A, B, C])
+}
+
+// Unzip splits a Result of a Pair into two Results.
+// If r is Err, both returned Results hold its error.
+// A nil member, which Ok would panic on, gives an Err holding ErrNilValue.
+func Unzip[A, B any](r Result[tuple.Pair[A, B]]) (Result[A], Result[B]) {
+	if r.err != nil {
+		return Err[A](r.err), Err[B](r.err)
+	}
+	return unzipped(r.value.First), unzipped(r.value.Second)
+}
+
+// Unzip3 splits a Result of a Triple into three Results.
+// If r is Err, all returned Results hold its error.
+// A nil member, which Ok would panic on, gives an Err holding ErrNilValue.
+func Unzip3[A, B, C any](r Result[tuple.Triple[A, B, C]]) (Result[A], Result[B], Result[C]) {
+	if r.err != nil {
+		return Err[A](r.err), Err[B](r.err), Err[C](r.err)
+	}
+	return unzipped(r.value.First), unzipped(r.value.Second), unzipped(r.value.Third)
+}
+
+// unzipped wraps a tuple member in Ok, or in an Err holding ErrNilValue when it is nil.
+func unzipped[T any](v T) Result[T] {
+	if go_utils.IsNil(v) {
+		return Err[T](ErrNilValue)
+	}
+	return Ok(v)
+}
+
+// Collect turns a list of Results into a Result of the list of their values.
+// It returns the first Err in the order of rs, or Ok with all values.
+func Collect[T any](rs []Result[T]) Result[[]T] {
+	values := make([]T, len(rs))
+	for i, r := range rs {
+		if r.err != nil {
+			return Err[[]T](r.err)
+		}
+		values[i] = r.value
+	}
+	return Ok(values)
+}
+
+// firstErr returns the first non-nil error, or nil if there is none.
+func firstErr(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
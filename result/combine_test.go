@@ -0,0 +1,140 @@
+package result
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/azat-dev/go-utils/tuple"
+)
+
+type person struct {
+	name string
+	age  int
+}
+
+func TestMap2(t *testing.T) {
+	t.Run("all Ok", func(t *testing.T) {
+		result := Map2(Ok("alice"), Ok(30), func(name string, age int) person {
+			return person{name: name, age: age}
+		})
+		p := result.Unwrap()
+		if p.name != "alice" || p.age != 30 {
+			t.Errorf("Unexpected person %+v", p)
+		}
+	})
+
+	t.Run("first Err wins", func(t *testing.T) {
+		firstErr := errors.New("first")
+		secondErr := errors.New("second")
+		called := false
+		result := Map2(Err[string](firstErr), Err[int](secondErr), func(string, int) person {
+			called = true
+			return person{}
+		})
+		if _, err := result.Get(); err != firstErr {
+			t.Errorf("Expected error '%v', got '%v'", firstErr, err)
+		}
+		if called {
+			t.Error("Expected f not to be called")
+		}
+	})
+}
+
+func TestMap5(t *testing.T) {
+	sum := func(a, b, c, d, e int) int { return a + b + c + d + e }
+
+	if value := Map5(Ok(1), Ok(2), Ok(3), Ok(4), Ok(5), sum).Unwrap(); value != 15 {
+		t.Errorf("Expected 15, got %d", value)
+	}
+
+	testErr := errors.New("test error")
+	result := Map5(Ok(1), Ok(2), Ok(3), Err[int](testErr), Ok(5), sum)
+	if _, err := result.Get(); err != testErr {
+		t.Errorf("Expected error '%v', got '%v'", testErr, err)
+	}
+
+	if value := Map3(Ok(1), Ok(2), Ok(3), func(a, b, c int) int { return a + b + c }).Unwrap(); value != 6 {
+		t.Errorf("Expected 6, got %d", value)
+	}
+	if value := Map4(Ok(1), Ok(2), Ok(3), Ok(4), func(a, b, c, d int) int { return a + b + c + d }).Unwrap(); value != 10 {
+		t.Errorf("Expected 10, got %d", value)
+	}
+}
+
+func TestZip(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		pair := Zip(Ok("a"), Ok(1)).Unwrap()
+		if pair != tuple.NewPair("a", 1) {
+			t.Errorf("Unexpected pair %+v", pair)
+		}
+		triple := Zip3(Ok("a"), Ok(1), Ok(true)).Unwrap()
+		if triple != tuple.NewTriple("a", 1, true) {
+			t.Errorf("Unexpected triple %+v", triple)
+		}
+	})
+
+	t.Run("Err", func(t *testing.T) {
+		testErr := errors.New("test error")
+		if !Zip(Ok("a"), Err[int](testErr)).ErrIs(testErr) {
+			t.Error("Expected Zip to return the Err")
+		}
+	})
+}
+
+func TestUnzip(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		a, b := Unzip(Ok(tuple.NewPair("a", 1)))
+		if a.Unwrap() != "a" || b.Unwrap() != 1 {
+			t.Errorf("Unexpected values %v %v", a, b)
+		}
+		x, y, z := Unzip3(Ok(tuple.NewTriple("a", 1, true)))
+		if x.Unwrap() != "a" || y.Unwrap() != 1 || !z.Unwrap() {
+			t.Errorf("Unexpected values %v %v %v", x, y, z)
+		}
+	})
+
+	t.Run("Err", func(t *testing.T) {
+		testErr := errors.New("test error")
+		a, b := Unzip(Err[tuple.Pair[string, int]](testErr))
+		if !a.ErrIs(testErr) || !b.ErrIs(testErr) {
+			t.Error("Expected both Results to hold the error")
+		}
+	})
+
+	t.Run("nil member", func(t *testing.T) {
+		a, b := Unzip(Ok(tuple.Pair[*int, int]{First: nil, Second: 1}))
+		if !a.ErrIs(ErrNilValue) || ErrAs[*PanicError](a).IsSome() {
+			t.Errorf("Expected ErrNilValue for the nil member, got %v", a)
+		}
+		if b.Unwrap() != 1 {
+			t.Errorf("Expected Ok(1), got %v", b)
+		}
+		_, _, z := Unzip3(Ok(tuple.Triple[int, string, error]{First: 1, Second: "a", Third: nil}))
+		if !z.ErrIs(ErrNilValue) {
+			t.Errorf("Expected ErrNilValue for the nil member, got %v", z)
+		}
+	})
+}
+
+func TestCollect(t *testing.T) {
+	t.Run("all Ok", func(t *testing.T) {
+		values := Collect([]Result[int]{Ok(1), Ok(2)}).Unwrap()
+		if len(values) != 2 || values[0] != 1 || values[1] != 2 {
+			t.Errorf("Expected [1 2], got %v", values)
+		}
+	})
+
+	t.Run("first Err", func(t *testing.T) {
+		firstErr := errors.New("first")
+		result := Collect([]Result[int]{Ok(1), Err[int](firstErr), Err[int](errors.New("second"))})
+		if _, err := result.Get(); err != firstErr {
+			t.Errorf("Expected error '%v', got '%v'", firstErr, err)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		if values := Collect[int](nil).Unwrap(); len(values) != 0 {
+			t.Errorf("Expected empty slice, got %v", values)
+		}
+	})
+}
@@ -0,0 +1,53 @@
+package result
+
+import (
+	"context"
+	"time"
+)
+
+// WithTimeout calls f with a context that is cancelled after d and returns its Result.
+// If d elapses before f returns, it returns an Err wrapping context.DeadlineExceeded without waiting for f,
+// which keeps running in its own goroutine until it notices the cancellation.
+// Cancellation of ctx itself is returned as an Err holding context.Cause(ctx).
+// A panic in f is recovered into an Err holding a *PanicError.
+func WithTimeout[T any](ctx context.Context, d time.Duration, f func(context.Context) Result[T]) Result[T] {
+	ctx, cancel := context.WithTimeout(ctx, d)
+	defer cancel()
+
+	done := make(chan Result[T], 1)
+	go func() {
+		done <- callWithContext(ctx, f)
+	}()
+
+	select {
+	case r := <-done:
+		return r
+	case <-ctx.Done():
+		// select picks at random when f finished just as ctx was done; keep the completed Result then.
+		select {
+		case r := <-done:
+			return r
+		default:
+			return Err[T](context.Cause(ctx))
+		}
+	}
+}
+
+// FromContext returns an Err holding context.Cause(ctx) if ctx is done, and Ok otherwise.
+// Useful as an early exit before starting expensive work:
+//
+//	if r := result.FromContext(ctx); r.IsErr() {
+//		return result.Err[User](r.UnwrapErr())
+//	}
+func FromContext(ctx context.Context) Result[struct{}] {
+	if ctx.Err() != nil {
+		return Err[struct{}](context.Cause(ctx))
+	}
+	return Ok(struct{}{})
+}
+
+// callWithContext calls f and converts a panic into an Err.
+func callWithContext[T any](ctx context.Context, f func(context.Context) Result[T]) (r Result[T]) {
+	defer Catch(&r)
+	return f(ctx)
+}
@@ -0,0 +1,69 @@
+package result
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWithTimeout(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("returns Result before deadline", func(t *testing.T) {
+		r := WithTimeout(ctx, time.Second, func(context.Context) Result[int] { return Ok(42) })
+		if v := r.Unwrap(); v != 42 {
+			t.Errorf("Expected 42, got %d", v)
+		}
+	})
+
+	t.Run("deadline exceeded", func(t *testing.T) {
+		sawCancel := make(chan struct{})
+		r := WithTimeout(ctx, 10*time.Millisecond, func(ctx context.Context) Result[int] {
+			<-ctx.Done()
+			close(sawCancel)
+			time.Sleep(10 * time.Millisecond)
+			return Ok(1)
+		})
+		if !r.ErrIs(context.DeadlineExceeded) {
+			t.Errorf("Expected context.DeadlineExceeded, got %v", r)
+		}
+		select {
+		case <-sawCancel:
+		case <-time.After(time.Second):
+			t.Error("Expected inner function to see cancellation")
+		}
+	})
+
+	t.Run("parent cancelled", func(t *testing.T) {
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		r := WithTimeout(cancelled, time.Second, func(ctx context.Context) Result[int] {
+			<-ctx.Done()
+			return Ok(1)
+		})
+		if !r.ErrIs(context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", r)
+		}
+	})
+
+	t.Run("recovers panic", func(t *testing.T) {
+		r := WithTimeout(ctx, time.Second, func(context.Context) Result[int] { panic("boom") })
+		if ErrAs[*PanicError](r).IsNone() {
+			t.Errorf("Expected *PanicError, got %v", r)
+		}
+	})
+}
+
+func TestFromContext(t *testing.T) {
+	if r := FromContext(context.Background()); r.IsErr() {
+		t.Errorf("Expected Ok for live context, got %v", r)
+	}
+
+	cause := errors.New("shutting down")
+	cancelled, cancel := context.WithCancelCause(context.Background())
+	cancel(cause)
+	if r := FromContext(cancelled); !r.ErrIs(cause) {
+		t.Errorf("Expected Err holding the cause, got %v", r)
+	}
+}
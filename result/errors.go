@@ -0,0 +1,52 @@
+package result
+
+import (
+	"errors"
+
+	"github.com/azat-dev/go-utils/optional"
+)
+
+// ErrIs reports whether the Result is Err and its error matches target according to errors.Is.
+// An Ok Result never matches.
+func (r Result[T]) ErrIs(target error) bool {
+	return r.err != nil && errors.Is(r.err, target)
+}
+
+// IsErrAnd reports whether the Result is Err and the predicate returns true for its error.
+// The predicate is not called for an Ok Result.
+func (r Result[T]) IsErrAnd(pred func(error) bool) bool {
+	return r.err != nil && pred(r.err)
+}
+
+// ErrAs finds the first error in the chain of an Err Result that matches type E, as errors.As does.
+// It returns Some with the matched error, or None if the Result is Ok or nothing in the chain matches.
+// E must be an interface type or a type implementing error, otherwise errors.As panics.
+func ErrAs[E any, T any](r Result[T]) optional.Optional[E] {
+	if r.err == nil {
+		return optional.None[E]()
+	}
+	var target E
+	if errors.As(r.err, &target) {
+		return optional.NewFromNullable(target)
+	}
+	return optional.None[E]()
+}
+
+// RecoverIs replaces an Err Result whose error matches target (errors.Is) with the Result returned by fallback.
+// Ok Results and errors that don't match are returned unchanged.
+func RecoverIs[T any](r Result[T], target error, fallback func(error) Result[T]) Result[T] {
+	if r.ErrIs(target) {
+		return fallback(r.err)
+	}
+	return r
+}
+
+// RecoverAs replaces an Err Result whose error chain contains an error of type E (errors.As)
+// with the Result returned by f, which receives the matched error.
+// Ok Results and errors that don't match are returned unchanged.
+func RecoverAs[E any, T any](r Result[T], f func(E) Result[T]) Result[T] {
+	if e, ok := ErrAs[E](r).Get(); ok {
+		return f(e)
+	}
+	return r
+}
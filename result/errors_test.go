@@ -0,0 +1,146 @@
+package result
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+var errNotFound = errors.New("not found")
+
+type codeError struct {
+	code int
+}
+
+func (e *codeError) Error() string {
+	return fmt.Sprintf("code %d", e.code)
+}
+
+func TestErrIs(t *testing.T) {
+	t.Run("Err with matching wrapped error", func(t *testing.T) {
+		result := Err[string](fmt.Errorf("load user: %w", errNotFound))
+		if !result.ErrIs(errNotFound) {
+			t.Error("Expected ErrIs to match wrapped error")
+		}
+	})
+
+	t.Run("Err with other error", func(t *testing.T) {
+		result := Err[string](errors.New("other"))
+		if result.ErrIs(errNotFound) {
+			t.Error("Expected ErrIs to return false for non-matching error")
+		}
+	})
+
+	t.Run("Ok result", func(t *testing.T) {
+		result := Ok("hello")
+		if result.ErrIs(errNotFound) {
+			t.Error("Expected ErrIs to return false for Ok")
+		}
+	})
+}
+
+func TestIsErrAnd(t *testing.T) {
+	t.Run("Err with predicate true", func(t *testing.T) {
+		result := Err[int](errNotFound)
+		if !result.IsErrAnd(func(err error) bool { return err == errNotFound }) {
+			t.Error("Expected IsErrAnd to return true")
+		}
+	})
+
+	t.Run("Err with predicate false", func(t *testing.T) {
+		result := Err[int](errNotFound)
+		if result.IsErrAnd(func(error) bool { return false }) {
+			t.Error("Expected IsErrAnd to return false")
+		}
+	})
+
+	t.Run("Ok result does not call predicate", func(t *testing.T) {
+		called := false
+		result := Ok(1)
+		if result.IsErrAnd(func(error) bool { called = true; return true }) {
+			t.Error("Expected IsErrAnd to return false for Ok")
+		}
+		if called {
+			t.Error("Expected predicate not to be called for Ok")
+		}
+	})
+}
+
+func TestErrAs(t *testing.T) {
+	t.Run("Err with matching type", func(t *testing.T) {
+		result := Err[string](fmt.Errorf("wrap: %w", &codeError{code: 404}))
+		opt := ErrAs[*codeError](result)
+		e, ok := opt.Get()
+		if !ok {
+			t.Fatal("Expected ErrAs to return Some")
+		}
+		if e.code != 404 {
+			t.Errorf("Expected code 404, got %d", e.code)
+		}
+	})
+
+	t.Run("Err with other type", func(t *testing.T) {
+		result := Err[string](errNotFound)
+		if ErrAs[*codeError](result).IsSome() {
+			t.Error("Expected ErrAs to return None for non-matching type")
+		}
+	})
+
+	t.Run("Ok result", func(t *testing.T) {
+		result := Ok("hello")
+		if ErrAs[*codeError](result).IsSome() {
+			t.Error("Expected ErrAs to return None for Ok")
+		}
+	})
+}
+
+func TestRecoverIs(t *testing.T) {
+	fallback := func(error) Result[string] { return Ok("fallback") }
+
+	t.Run("matching error is replaced", func(t *testing.T) {
+		result := RecoverIs(Err[string](fmt.Errorf("wrap: %w", errNotFound)), errNotFound, fallback)
+		if value := result.UnwrapOr("default"); value != "fallback" {
+			t.Errorf("Expected 'fallback', got '%s'", value)
+		}
+	})
+
+	t.Run("other error passes through", func(t *testing.T) {
+		otherErr := errors.New("other")
+		result := RecoverIs(Err[string](otherErr), errNotFound, fallback)
+		if _, err := result.Get(); err != otherErr {
+			t.Errorf("Expected error '%v', got '%v'", otherErr, err)
+		}
+	})
+
+	t.Run("Ok passes through", func(t *testing.T) {
+		result := RecoverIs(Ok("hello"), errNotFound, fallback)
+		if value := result.UnwrapOr("default"); value != "hello" {
+			t.Errorf("Expected 'hello', got '%s'", value)
+		}
+	})
+}
+
+func TestRecoverAs(t *testing.T) {
+	fallback := func(e *codeError) Result[int] { return Ok(e.code) }
+
+	t.Run("matching error is replaced", func(t *testing.T) {
+		result := RecoverAs(Err[int](fmt.Errorf("wrap: %w", &codeError{code: 7})), fallback)
+		if value := result.UnwrapOr(0); value != 7 {
+			t.Errorf("Expected 7, got %d", value)
+		}
+	})
+
+	t.Run("other error passes through", func(t *testing.T) {
+		result := RecoverAs(Err[int](errNotFound), fallback)
+		if _, err := result.Get(); err != errNotFound {
+			t.Errorf("Expected error '%v', got '%v'", errNotFound, err)
+		}
+	})
+
+	t.Run("Ok passes through", func(t *testing.T) {
+		result := RecoverAs(Ok(3), fallback)
+		if value := result.UnwrapOr(0); value != 3 {
+			t.Errorf("Expected 3, got %d", value)
+		}
+	})
+}
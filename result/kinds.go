@@ -0,0 +1,68 @@
+package result
+
+import "github.com/azat-dev/go-utils/kinds"
+
+// ErrKind creates an Err Result holding an error of the given kind, formatted as fmt.Errorf does.
+func ErrKind[T any](kind kinds.Kind, format string, a ...any) Result[T] {
+	return Err[T](kinds.Errorf(kind, format, a...))
+}
+
+// IsErrKind reports whether the Result is Err and kinds.KindOf its error is kind.
+func (r Result[T]) IsErrKind(kind kinds.Kind) bool {
+	return r.err != nil && kinds.KindOf(r.err) == kind
+}
+
+// InvalidArgument creates an Err Result holding a kinds.InvalidArgument error.
+func InvalidArgument[T any](format string, a ...any) Result[T] {
+	return ErrKind[T](kinds.InvalidArgument, format, a...)
+}
+
+// NotFound creates an Err Result holding a kinds.NotFound error.
+func NotFound[T any](format string, a ...any) Result[T] {
+	return ErrKind[T](kinds.NotFound, format, a...)
+}
+
+// AlreadyExists creates an Err Result holding a kinds.AlreadyExists error.
+func AlreadyExists[T any](format string, a ...any) Result[T] {
+	return ErrKind[T](kinds.AlreadyExists, format, a...)
+}
+
+// Conflict creates an Err Result holding a kinds.Conflict error.
+func Conflict[T any](format string, a ...any) Result[T] {
+	return ErrKind[T](kinds.Conflict, format, a...)
+}
+
+// FailedPrecondition creates an Err Result holding a kinds.FailedPrecondition error.
+func FailedPrecondition[T any](format string, a ...any) Result[T] {
+	return ErrKind[T](kinds.FailedPrecondition, format, a...)
+}
+
+// Unauthenticated creates an Err Result holding a kinds.Unauthenticated error.
+func Unauthenticated[T any](format string, a ...any) Result[T] {
+	return ErrKind[T](kinds.Unauthenticated, format, a...)
+}
+
+// PermissionDenied creates an Err Result holding a kinds.PermissionDenied error.
+func PermissionDenied[T any](format string, a ...any) Result[T] {
+	return ErrKind[T](kinds.PermissionDenied, format, a...)
+}
+
+// ResourceExhausted creates an Err Result holding a kinds.ResourceExhausted error.
+func ResourceExhausted[T any](format string, a ...any) Result[T] {
+	return ErrKind[T](kinds.ResourceExhausted, format, a...)
+}
+
+// Unavailable creates an Err Result holding a kinds.Unavailable error.
+func Unavailable[T any](format string, a ...any) Result[T] {
+	return ErrKind[T](kinds.Unavailable, format, a...)
+}
+
+// Unimplemented creates an Err Result holding a kinds.Unimplemented error.
+func Unimplemented[T any](format string, a ...any) Result[T] {
+	return ErrKind[T](kinds.Unimplemented, format, a...)
+}
+
+// Internal creates an Err Result holding a kinds.Internal error.
+func Internal[T any](format string, a ...any) Result[T] {
+	return ErrKind[T](kinds.Internal, format, a...)
+}
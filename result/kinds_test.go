@@ -0,0 +1,55 @@
+package result
+
+import (
+	"testing"
+
+	"github.com/azat-dev/go-utils/kinds"
+)
+
+func TestKindConstructors(t *testing.T) {
+	tests := []struct {
+		name   string
+		result Result[int]
+		kind   kinds.Kind
+	}{
+		{"InvalidArgument", InvalidArgument[int]("bad id %q", "x"), kinds.InvalidArgument},
+		{"NotFound", NotFound[int]("user %d", 7), kinds.NotFound},
+		{"AlreadyExists", AlreadyExists[int]("user %d", 7), kinds.AlreadyExists},
+		{"Conflict", Conflict[int]("user %d", 7), kinds.Conflict},
+		{"FailedPrecondition", FailedPrecondition[int]("user %d", 7), kinds.FailedPrecondition},
+		{"Unauthenticated", Unauthenticated[int]("user %d", 7), kinds.Unauthenticated},
+		{"PermissionDenied", PermissionDenied[int]("user %d", 7), kinds.PermissionDenied},
+		{"ResourceExhausted", ResourceExhausted[int]("user %d", 7), kinds.ResourceExhausted},
+		{"Unavailable", Unavailable[int]("user %d", 7), kinds.Unavailable},
+		{"Unimplemented", Unimplemented[int]("user %d", 7), kinds.Unimplemented},
+		{"Internal", Internal[int]("user %d", 7), kinds.Internal},
+		{"ErrKind", ErrKind[int](kinds.Conflict, "user %d", 7), kinds.Conflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.result.Get()
+			if err == nil {
+				t.Fatal("Expected Err")
+			}
+			if got := kinds.KindOf(err); got != tt.kind {
+				t.Errorf("Expected kind %v, got %v", tt.kind, got)
+			}
+			if !tt.result.IsErrKind(tt.kind) {
+				t.Errorf("Expected IsErrKind(%v) to be true", tt.kind)
+			}
+		})
+	}
+
+	if _, err := NotFound[int]("user %d", 7).Get(); err.Error() != "user 7" {
+		t.Errorf("Expected message 'user 7', got '%v'", err)
+	}
+}
+
+func TestIsErrKind(t *testing.T) {
+	if Ok(1).IsErrKind(kinds.Unknown) {
+		t.Error("Expected IsErrKind to be false for Ok")
+	}
+	if NotFound[int]("x").IsErrKind(kinds.Conflict) {
+		t.Error("Expected IsErrKind to be false for another kind")
+	}
+}
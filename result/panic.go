@@ -0,0 +1,85 @@
+package result
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	go_utils "github.com/azat-dev/go-utils"
+)
+
+// PanicError is the error stored in an Err Result when a panic was recovered by Try, TryValue or Catch.
+// Value holds the value passed to panic, and Stack holds the goroutine stack captured at the recover point.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+// Error returns a message describing the recovered panic value.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("recovered panic: %v", e.Value)
+}
+
+// Unwrap returns the panic value if it is an error, so errors.Is and errors.As can see through the panic.
+func (e *PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// UnwrapError is the value Unwrap and MustGet panic with when called on an Err Result.
+// It wraps the original error, so it can be recovered and inspected with errors.Is and errors.As.
+type UnwrapError struct {
+	Method string
+	Err    error
+}
+
+// Error returns the panic message, including the method name and the original error.
+func (e *UnwrapError) Error() string {
+	return "called " + e.Method + "() on an Err Result: " + e.Err.Error()
+}
+
+// Unwrap returns the original error of the Err Result.
+func (e *UnwrapError) Unwrap() error {
+	return e.Err
+}
+
+// Try calls f and converts its return values into a Result, as From does.
+// If f panics, the panic is recovered and returned as an Err holding a *PanicError.
+func Try[T any](f func() (T, error)) (r Result[T]) {
+	defer Catch(&r)
+	return From(f())
+}
+
+// TryValue calls f and returns its value as Ok.
+// If f panics, the panic is recovered and returned as an Err holding a *PanicError.
+func TryValue[T any](f func() T) (r Result[T]) {
+	defer Catch(&r)
+	return Ok(f())
+}
+
+// From converts the (value, error) pair returned by idiomatic Go functions into a Result.
+// A non-nil error gives Err, otherwise the value is wrapped with Ok.
+// A nil value without an error gives an Err holding ErrNilValue, since Ok rejects nil.
+func From[T any](v T, err error) Result[T] {
+	if err != nil {
+		return Err[T](err)
+	}
+	if go_utils.IsNil(v) {
+		return Err[T](ErrNilValue)
+	}
+	return Ok(v)
+}
+
+// Catch recovers a panic and stores it in *r as an Err holding a *PanicError.
+// It must be deferred directly in the function that owns the named Result:
+//
+//	func load() (r result.Result[Config]) {
+//		defer result.Catch(&r)
+//		...
+//	}
+func Catch[T any](r *Result[T]) {
+	if v := recover(); v != nil {
+		*r = Err[T](&PanicError{Value: v, Stack: debug.Stack()})
+	}
+}
@@ -0,0 +1,107 @@
+package result
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTry(t *testing.T) {
+	t.Run("returns Ok for value", func(t *testing.T) {
+		result := Try(func() (int, error) { return 42, nil })
+		if value := result.UnwrapOr(0); value != 42 {
+			t.Errorf("Expected 42, got %d", value)
+		}
+	})
+
+	t.Run("returns Err for error", func(t *testing.T) {
+		testErr := errors.New("test error")
+		result := Try(func() (int, error) { return 0, testErr })
+		if _, err := result.Get(); err != testErr {
+			t.Errorf("Expected error '%v', got '%v'", testErr, err)
+		}
+	})
+
+	t.Run("recovers panic", func(t *testing.T) {
+		result := Try(func() (int, error) { panic("boom") })
+		var panicErr *PanicError
+		if !errors.As(result.UnwrapErr(), &panicErr) {
+			t.Fatalf("Expected *PanicError, got %T", result.UnwrapErr())
+		}
+		if panicErr.Value != "boom" {
+			t.Errorf("Expected panic value 'boom', got '%v'", panicErr.Value)
+		}
+		if len(panicErr.Stack) == 0 {
+			t.Error("Expected stack to be captured")
+		}
+		if panicErr.Error() != "recovered panic: boom" {
+			t.Errorf("Unexpected error message '%s'", panicErr.Error())
+		}
+	})
+
+	t.Run("panic with error is unwrappable", func(t *testing.T) {
+		testErr := errors.New("test error")
+		result := Try(func() (int, error) { panic(testErr) })
+		if !result.ErrIs(testErr) {
+			t.Error("Expected PanicError to unwrap to the panic value")
+		}
+	})
+}
+
+func TestTryValue(t *testing.T) {
+	t.Run("returns Ok for value", func(t *testing.T) {
+		result := TryValue(func() string { return "hello" })
+		if value := result.UnwrapOr("default"); value != "hello" {
+			t.Errorf("Expected 'hello', got '%s'", value)
+		}
+	})
+
+	t.Run("recovers panic", func(t *testing.T) {
+		result := TryValue(func() string {
+			var m map[string]int
+			m["x"] = 1
+			return "unreachable"
+		})
+		if ErrAs[*PanicError](result).IsNone() {
+			t.Errorf("Expected *PanicError, got %v", result.UnwrapErr())
+		}
+	})
+}
+
+func TestFrom(t *testing.T) {
+	t.Run("value", func(t *testing.T) {
+		result := From(5, nil)
+		if value := result.UnwrapOr(0); value != 5 {
+			t.Errorf("Expected 5, got %d", value)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		testErr := errors.New("test error")
+		result := From(5, testErr)
+		if _, err := result.Get(); err != testErr {
+			t.Errorf("Expected error '%v', got '%v'", testErr, err)
+		}
+	})
+
+	t.Run("nil value gives ErrNilValue", func(t *testing.T) {
+		var ptr *int
+		result := From(ptr, nil)
+		if !result.ErrIs(ErrNilValue) {
+			t.Errorf("Expected ErrNilValue, got %v", result)
+		}
+		if ErrAs[*PanicError](result).IsSome() {
+			t.Error("Expected no *PanicError for a nil value")
+		}
+	})
+}
+
+func TestCatch(t *testing.T) {
+	load := func() (r Result[int]) {
+		defer Catch(&r)
+		panic("boom")
+	}
+	result := load()
+	if ErrAs[*PanicError](result).IsNone() {
+		t.Errorf("Expected *PanicError, got %v", result.UnwrapErr())
+	}
+}
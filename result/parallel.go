@@ -0,0 +1,78 @@
+package result
+
+import (
+	"context"
+	"sync"
+)
+
+// ParallelMap calls f for every item using at most concurrency goroutines and returns the Results
+// in the order of items. If concurrency is less than 1, all items are processed at once.
+// Items that haven't started when ctx is done get an Err holding context.Cause(ctx).
+// A panic in f is recovered into an Err holding a *PanicError for that item only.
+// Use Collect on the returned slice to get the first error or all values.
+func ParallelMap[T, U any](
+	ctx context.Context,
+	items []T,
+	concurrency int,
+	f func(context.Context, T) Result[U],
+) []Result[U] {
+	return parallelMap(ctx, items, concurrency, false, f)
+}
+
+// ParallelMapFailFast works like ParallelMap, but cancels the context passed to f as soon as
+// any item returns an Err. Items that haven't started by then get an Err holding context.Canceled.
+func ParallelMapFailFast[T, U any](
+	ctx context.Context,
+	items []T,
+	concurrency int,
+	f func(context.Context, T) Result[U],
+) []Result[U] {
+	return parallelMap(ctx, items, concurrency, true, f)
+}
+
+func parallelMap[T, U any](
+	ctx context.Context,
+	items []T,
+	concurrency int,
+	failFast bool,
+	f func(context.Context, T) Result[U],
+) []Result[U] {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if concurrency < 1 || concurrency > len(items) {
+		concurrency = len(items)
+	}
+
+	results := make([]Result[U], len(items))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(concurrency)
+	for range concurrency {
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if ctx.Err() != nil {
+					results[i] = Err[U](context.Cause(ctx))
+					continue
+				}
+				results[i] = callRecovering(ctx, items[i], f)
+				if failFast && results[i].IsErr() {
+					cancel()
+				}
+			}
+		}()
+	}
+	for i := range items {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return results
+}
+
+// callRecovering calls f and converts a panic into an Err.
+func callRecovering[T, U any](ctx context.Context, item T, f func(context.Context, T) Result[U]) (r Result[U]) {
+	defer Catch(&r)
+	return f(ctx, item)
+}
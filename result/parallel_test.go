@@ -0,0 +1,124 @@
+package result
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParallelMap(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("keeps input order", func(t *testing.T) {
+		items := []int{5, 4, 3, 2, 1}
+		results := ParallelMap(ctx, items, 3, func(_ context.Context, n int) Result[int] {
+			time.Sleep(time.Duration(n) * time.Millisecond)
+			return Ok(n * 10)
+		})
+		values := Collect(results).Unwrap()
+		for i, n := range items {
+			if values[i] != n*10 {
+				t.Errorf("Expected %d at index %d, got %d", n*10, i, values[i])
+			}
+		}
+	})
+
+	t.Run("limits concurrency", func(t *testing.T) {
+		var running, peak atomic.Int32
+		ParallelMap(ctx, make([]int, 20), 4, func(context.Context, int) Result[int] {
+			n := running.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			running.Add(-1)
+			return Ok(0)
+		})
+		if p := peak.Load(); p > 4 {
+			t.Errorf("Expected at most 4 concurrent calls, got %d", p)
+		}
+	})
+
+	t.Run("recovers panic per item", func(t *testing.T) {
+		results := ParallelMap(ctx, []int{1, 2, 3}, 0, func(_ context.Context, n int) Result[int] {
+			if n == 2 {
+				panic("boom")
+			}
+			return Ok(n)
+		})
+		if results[0].Unwrap() != 1 || results[2].Unwrap() != 3 {
+			t.Error("Expected other items to succeed")
+		}
+		if ErrAs[*PanicError](results[1]).IsNone() {
+			t.Errorf("Expected *PanicError, got %v", results[1])
+		}
+	})
+
+	t.Run("honours cancelled context", func(t *testing.T) {
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		var calls atomic.Int32
+		results := ParallelMap(cancelled, []int{1, 2}, 1, func(_ context.Context, n int) Result[int] {
+			calls.Add(1)
+			return Ok(n)
+		})
+		if calls.Load() != 0 {
+			t.Errorf("Expected no calls, got %d", calls.Load())
+		}
+		for _, r := range results {
+			if !r.ErrIs(context.Canceled) {
+				t.Errorf("Expected context.Canceled, got %v", r)
+			}
+		}
+	})
+
+	t.Run("does not stop on error", func(t *testing.T) {
+		testErr := errors.New("test error")
+		results := ParallelMap(ctx, []int{1, 2, 3}, 1, func(_ context.Context, n int) Result[int] {
+			if n == 1 {
+				return Err[int](testErr)
+			}
+			return Ok(n)
+		})
+		if !results[0].ErrIs(testErr) || results[1].Unwrap() != 2 || results[2].Unwrap() != 3 {
+			t.Errorf("Unexpected results %v", results)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		if results := ParallelMap(ctx, []int{}, 2, func(context.Context, int) Result[int] { return Ok(0) }); len(results) != 0 {
+			t.Errorf("Expected no results, got %v", results)
+		}
+	})
+}
+
+func TestParallelMapFailFast(t *testing.T) {
+	testErr := errors.New("test error")
+	var calls atomic.Int32
+	results := ParallelMapFailFast(context.Background(), []int{1, 2, 3, 4}, 1, func(_ context.Context, n int) Result[int] {
+		calls.Add(1)
+		if n == 2 {
+			return Err[int](testErr)
+		}
+		return Ok(n)
+	})
+	if calls.Load() != 2 {
+		t.Errorf("Expected 2 calls, got %d", calls.Load())
+	}
+	if results[0].Unwrap() != 1 || !results[1].ErrIs(testErr) {
+		t.Errorf("Unexpected results %v", results)
+	}
+	for _, r := range results[2:] {
+		if !r.ErrIs(context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", r)
+		}
+	}
+	if _, err := Collect(results).Get(); err != testErr {
+		t.Errorf("Expected Collect to return '%v', got '%v'", testErr, err)
+	}
+}
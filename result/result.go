@@ -1,6 +1,7 @@
 package result
 
 import (
+	"errors"
 	"fmt"
 
 	go_utils "github.com/azat-dev/go-utils"
@@ -8,6 +9,9 @@ import (
 	"github.com/azat-dev/go-utils/optional"
 )
 
+// ErrNilValue is the error of the Err Result returned in place of a nil value, which Ok rejects.
+var ErrNilValue = errors.New("result: nil value")
+
 // Result represents the outcome of an operation, which can either be a successful value (Ok) or an error (Err).
 // T - the type of the successful value.
 type Result[T any] struct {
@@ -27,7 +31,7 @@ func Ok[T any](v T) Result[T] {
 }
 
 // Err creates a Result with an error.
-// Use this to indicate a failed operation.
+// Use this to indicate a failed operation. See ErrWithStack to also record where the error was created.
 func Err[T any](e error) Result[T] {
 	var zero T // Zero value for type T
 	return Result[T]{value: zero, err: e}
@@ -56,11 +60,11 @@ func (r Result[T]) Get() (T, error) {
 	return r.value, r.err
 }
 
-// Unwrap returns the successful value if it's present. Otherwise, it panics with the error.
+// Unwrap returns the successful value if it's present. Otherwise, it panics with an *UnwrapError wrapping the error.
 // Use when you are certain the operation was successful.
 func (r Result[T]) Unwrap() T {
 	if r.err != nil {
-		panic("called Unwrap() on an Err Result: " + r.err.Error())
+		panic(&UnwrapError{Method: "Unwrap", Err: r.err})
 	}
 	return r.value
 }
@@ -82,11 +86,11 @@ func (r Result[T]) UnwrapErr() error {
 	return r.err
 }
 
-// MustGet returns the successful value. If there's an error, it panics.
+// MustGet returns the successful value. If there's an error, it panics with an *UnwrapError wrapping the error.
 // Convenient for initialization or test code where errors are not expected.
 func (r Result[T]) MustGet() T {
 	if r.err != nil {
-		panic("called MustGet() on an Err Result: " + r.err.Error())
+		panic(&UnwrapError{Method: "MustGet", Err: r.err})
 	}
 	return r.value
 }
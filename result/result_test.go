@@ -256,17 +256,29 @@ func TestUnwrap(t *testing.T) {
 	})
 
 	t.Run("Err result - should panic", func(t *testing.T) {
+		testErr := errors.New("test error")
 		defer func() {
 			if r := recover(); r == nil {
 				t.Error("Expected Unwrap on Err to panic")
 			} else {
+				err, ok := r.(error)
+				if !ok {
+					t.Fatalf("Expected panic value to be an error, got %T", r)
+				}
+				var unwrapErr *UnwrapError
+				if !errors.As(err, &unwrapErr) {
+					t.Fatalf("Expected panic value to be *UnwrapError, got %T", r)
+				}
+				if !errors.Is(err, testErr) {
+					t.Error("Expected panic value to wrap the original error")
+				}
 				expectedMsg := "called Unwrap() on an Err Result: test error"
-				if r != expectedMsg {
-					t.Errorf("Expected panic message '%s', got '%s'", expectedMsg, r)
+				if err.Error() != expectedMsg {
+					t.Errorf("Expected panic message '%s', got '%s'", expectedMsg, err.Error())
 				}
 			}
 		}()
-		result := Err[string](errors.New("test error"))
+		result := Err[string](testErr)
 		result.Unwrap()
 	})
 }
@@ -325,17 +337,29 @@ func TestMustGet(t *testing.T) {
 	})
 
 	t.Run("Err result - should panic", func(t *testing.T) {
+		testErr := errors.New("test error")
 		defer func() {
 			if r := recover(); r == nil {
 				t.Error("Expected MustGet on Err to panic")
 			} else {
+				err, ok := r.(error)
+				if !ok {
+					t.Fatalf("Expected panic value to be an error, got %T", r)
+				}
+				var unwrapErr *UnwrapError
+				if !errors.As(err, &unwrapErr) {
+					t.Fatalf("Expected panic value to be *UnwrapError, got %T", r)
+				}
+				if !errors.Is(err, testErr) {
+					t.Error("Expected panic value to wrap the original error")
+				}
 				expectedMsg := "called MustGet() on an Err Result: test error"
-				if r != expectedMsg {
-					t.Errorf("Expected panic message '%s', got '%s'", expectedMsg, r)
+				if err.Error() != expectedMsg {
+					t.Errorf("Expected panic message '%s', got '%s'", expectedMsg, err.Error())
 				}
 			}
 		}()
-		result := Err[string](errors.New("test error"))
+		result := Err[string](testErr)
 		result.MustGet()
 	})
 }
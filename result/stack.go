@@ -0,0 +1,82 @@
+package result
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+)
+
+const maxStackDepth = 32
+
+// StackError wraps an error together with the program counters of the call site that created it.
+// Frames are resolved lazily, only when StackTrace is called or the error is formatted with %+v.
+type StackError struct {
+	err error
+	pcs []uintptr
+}
+
+// ErrWithStack creates a Result with an error, like Err, and records the stack of the caller.
+// The stored error is a *StackError wrapping e. A nil e is stored as is.
+func ErrWithStack[T any](e error) Result[T] {
+	if e == nil {
+		return Err[T](nil)
+	}
+	return Err[T](newStackError(e, 3))
+}
+
+// ErrorFWithStack creates a Result with a formatted error, like ErrorF, and records the stack of the caller.
+func ErrorFWithStack[T any](format string, a ...any) Result[T] {
+	return Err[T](newStackError(fmt.Errorf(format, a...), 3))
+}
+
+// newStackError captures the program counters above skip frames of the current goroutine.
+func newStackError(err error, skip int) *StackError {
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(skip, pcs)
+	return &StackError{err: err, pcs: pcs[:n]}
+}
+
+// Error returns the message of the wrapped error.
+func (e *StackError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *StackError) Unwrap() error {
+	return e.err
+}
+
+// StackTrace resolves the recorded program counters into frames, innermost call first.
+// It returns an empty trace if no program counters were recorded.
+func (e *StackError) StackTrace() []runtime.Frame {
+	trace := make([]runtime.Frame, 0, len(e.pcs))
+	if len(e.pcs) == 0 {
+		return trace
+	}
+	frames := runtime.CallersFrames(e.pcs)
+	for {
+		frame, more := frames.Next()
+		trace = append(trace, frame)
+		if !more {
+			break
+		}
+	}
+	return trace
+}
+
+// Format implements fmt.Formatter.
+// %+v prints the message followed by one "function\n\tfile:line" entry per frame,
+// every other verb prints the message only.
+func (e *StackError) Format(s fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && s.Flag('+'):
+		_, _ = io.WriteString(s, e.Error())
+		for _, frame := range e.StackTrace() {
+			_, _ = fmt.Fprintf(s, "\n%s\n\t%s:%d", frame.Function, frame.File, frame.Line)
+		}
+	case verb == 'q':
+		_, _ = fmt.Fprintf(s, "%q", e.Error())
+	default:
+		_, _ = io.WriteString(s, e.Error())
+	}
+}
@@ -0,0 +1,82 @@
+package result
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestErrWithStack(t *testing.T) {
+	testErr := errors.New("test error")
+	result := ErrWithStack[string](testErr)
+
+	err := result.UnwrapErr()
+	if !errors.Is(err, testErr) {
+		t.Error("Expected stack error to unwrap to the original error")
+	}
+	if errors.Unwrap(err) != testErr {
+		t.Error("Expected errors.Unwrap to return the original error")
+	}
+	if err.Error() != "test error" {
+		t.Errorf("Expected message 'test error', got '%s'", err.Error())
+	}
+
+	stackErr := ErrAs[*StackError](result).Unwrap()
+	trace := stackErr.StackTrace()
+	if len(trace) == 0 {
+		t.Fatal("Expected non-empty stack trace")
+	}
+	if !strings.HasSuffix(trace[0].Function, "TestErrWithStack") {
+		t.Errorf("Expected first frame to be the caller, got '%s'", trace[0].Function)
+	}
+}
+
+func TestErrWithStackNil(t *testing.T) {
+	result := ErrWithStack[string](nil)
+	if result.IsErr() {
+		t.Error("Expected nil error to be stored as is")
+	}
+}
+
+func TestErrorFWithStack(t *testing.T) {
+	result := ErrorFWithStack[int]("wrap: %w", errNotFound)
+	if !result.ErrIs(errNotFound) {
+		t.Error("Expected formatted stack error to wrap the original error")
+	}
+	if ErrAs[*StackError](result).IsNone() {
+		t.Error("Expected *StackError in the chain")
+	}
+}
+
+func TestStackErrorFormat(t *testing.T) {
+	err := ErrWithStack[int](errors.New("test error")).UnwrapErr()
+
+	if s := fmt.Sprintf("%v", err); s != "test error" {
+		t.Errorf("Expected '%%v' to print the message only, got '%s'", s)
+	}
+	if s := fmt.Sprintf("%s", err); s != "test error" {
+		t.Errorf("Expected '%%s' to print the message only, got '%s'", s)
+	}
+	if s := fmt.Sprintf("%q", err); s != `"test error"` {
+		t.Errorf("Expected '%%q' to quote the message, got '%s'", s)
+	}
+
+	verbose := fmt.Sprintf("%+v", err)
+	if !strings.HasPrefix(verbose, "test error\n") {
+		t.Errorf("Expected '%%+v' to start with the message, got '%s'", verbose)
+	}
+	if !strings.Contains(verbose, "TestStackErrorFormat") || !strings.Contains(verbose, "stack_test.go:") {
+		t.Errorf("Expected '%%+v' to include the caller frame, got '%s'", verbose)
+	}
+}
+
+func TestStackTraceWithoutFrames(t *testing.T) {
+	err := &StackError{err: errors.New("test error"), pcs: nil}
+	if trace := err.StackTrace(); len(trace) != 0 {
+		t.Errorf("Expected an empty trace, got %v", trace)
+	}
+	if s := fmt.Sprintf("%+v", err); s != "test error" {
+		t.Errorf("Expected '%%+v' to print the message only, got '%s'", s)
+	}
+}
@@ -0,0 +1,195 @@
+package resulthttp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/azat-dev/go-utils/kinds"
+	"github.com/azat-dev/go-utils/result"
+	"github.com/azat-dev/go-utils/validation"
+)
+
+const (
+	contentTypeJSON    = "application/json"
+	contentTypeProblem = "application/problem+json"
+	contentTypeText    = "text/plain; charset=utf-8"
+)
+
+// ErrorMapper turns the error of an Err Result into the Problem written as the response.
+// The Problem's Status is used as the response status; 0 means 500.
+type ErrorMapper func(r *http.Request, err error) Problem
+
+// Config controls how Results are written.
+type Config struct {
+	// ErrorMapper chooses the response for errors. If nil, DefaultErrorMapper is used.
+	ErrorMapper ErrorMapper
+	// OnError, if set, is called with every error and the Problem it was mapped to, for example to log
+	// the errors whose details DefaultErrorMapper keeps from the client.
+	OnError func(r *http.Request, err error, problem Problem)
+}
+
+// DefaultConfig returns a Config using DefaultErrorMapper and no OnError hook.
+func DefaultConfig() Config {
+	return Config{ErrorMapper: DefaultErrorMapper, OnError: nil}
+}
+
+// Handle returns a handler calling f and writing its Result with DefaultConfig.
+func Handle[T any](f func(*http.Request) result.Result[T]) http.Handler {
+	return HandleWith(DefaultConfig(), f)
+}
+
+// HandleWith returns a handler calling f and writing its Result:
+//
+//   - Ok is encoded as JSON with status 200, or 406 if the request does not accept application/json;
+//   - Err is mapped to a Problem by config.ErrorMapper and written as application/problem+json,
+//     application/json or plain text, whichever the request's Accept header prefers.
+//
+// A panic in f is recovered and handled as an Err holding a *result.PanicError,
+// except http.ErrAbortHandler, which is re-raised so net/http aborts the response.
+func HandleWith[T any](config Config, f func(*http.Request) result.Result[T]) http.Handler {
+	if config.ErrorMapper == nil {
+		config.ErrorMapper = DefaultErrorMapper
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept")
+
+		value, err := call(f, r).Get()
+		var panicErr *result.PanicError
+		if errors.As(err, &panicErr) && panicErr.Value == http.ErrAbortHandler {
+			panic(http.ErrAbortHandler)
+		}
+		if err != nil {
+			writeError(w, r, config, err)
+			return
+		}
+
+		if negotiate(r.Header.Get("Accept"), contentTypeJSON) == "" {
+			writeError(w, r, config, &Problem{
+				Status: http.StatusNotAcceptable,
+				Detail: "the response is only available as " + contentTypeJSON,
+			})
+			return
+		}
+		body, err := json.Marshal(value)
+		if err != nil {
+			writeError(w, r, config, fmt.Errorf("resulthttp: encoding response: %w", err))
+			return
+		}
+		write(w, http.StatusOK, contentTypeJSON, body)
+	})
+}
+
+func call[T any](f func(*http.Request) result.Result[T], r *http.Request) (res result.Result[T]) {
+	defer result.Catch(&res)
+	return f(r)
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, config Config, err error) {
+	problem := config.ErrorMapper(r, err)
+	if problem.Status == 0 {
+		problem.Status = http.StatusInternalServerError
+	}
+	if problem.Title == "" && problem.Type == "" {
+		problem.Title = statusText(problem.Status)
+	}
+	if config.OnError != nil {
+		config.OnError(r, err, problem)
+	}
+
+	contentType := negotiate(r.Header.Get("Accept"), contentTypeProblem, contentTypeJSON, "text/plain")
+	if contentType == "text/plain" {
+		write(w, problem.Status, contentTypeText, []byte(problem.Error()+"\n"))
+		return
+	}
+	// RFC 9110 allows ignoring Accept rather than answering 406 to an error.
+	if contentType == "" {
+		contentType = contentTypeProblem
+	}
+	body, err := json.Marshal(problem)
+	if err != nil {
+		// Only extensions can fail to encode; drop them rather than the whole response.
+		problem.Extensions = nil
+		body, _ = json.Marshal(problem)
+	}
+	write(w, problem.Status, contentType, body)
+}
+
+func write(w http.ResponseWriter, status int, contentType string, body []byte) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
+
+func statusText(status int) string {
+	if status == StatusClientClosedRequest {
+		return "Client Closed Request"
+	}
+	return http.StatusText(status)
+}
+
+// DefaultErrorMapper maps errors to Problems as follows:
+//
+//   - a *Problem in the chain is used as is;
+//   - a recovered panic (*result.PanicError) gives 500 without details;
+//   - an error declaring a kinds.Kind gives the kind's HTTP status, with that error's own message as detail
+//     unless the kind is kinds.Internal or kinds.Unknown; errors around it are left out, and so is the message
+//     when it wraps a cause, as with kinds.Wrap, since the cause may describe internals such as hosts or queries;
+//   - context.Canceled gives 499 and context.DeadlineExceeded gives 504;
+//   - validation.ValidationErrors gives 422 with an "errors" member listing {"field", "detail"} pairs;
+//   - anything else gives 500 without details, so internal messages don't reach clients.
+func DefaultErrorMapper(r *http.Request, err error) Problem {
+	var problem *Problem
+	var panicErr *result.PanicError
+	var classified kinds.Classified
+	var validationErrs validation.ValidationErrors
+	switch {
+	case errors.As(err, &problem):
+		return *problem
+	case errors.As(err, &panicErr):
+		return Problem{Status: http.StatusInternalServerError}
+	case errors.As(err, &classified):
+		kind := classified.Kind()
+		if kind == kinds.Internal || kind == kinds.Unknown {
+			return Problem{Status: http.StatusInternalServerError}
+		}
+		return Problem{Status: kind.HTTPStatus(), Detail: ownMessage(classified)}
+	case errors.Is(err, context.Canceled):
+		return Problem{Status: StatusClientClosedRequest, Detail: "the request was canceled"}
+	case errors.Is(err, context.DeadlineExceeded):
+		return Problem{Status: http.StatusGatewayTimeout, Detail: "the request timed out"}
+	case errors.As(err, &validationErrs):
+		fields := make([]map[string]string, len(validationErrs))
+		for i, fieldErr := range validationErrs {
+			fields[i] = map[string]string{"field": fieldErr.Field(), "detail": fieldErr.Err.Error()}
+		}
+		return Problem{
+			Status:     http.StatusUnprocessableEntity,
+			Detail:     "the request is invalid",
+			Extensions: map[string]any{"errors": fields},
+		}
+	}
+	return Problem{Status: http.StatusInternalServerError}
+}
+
+// ownMessage returns the message of classified if it is an error wrapping no other error, and "" otherwise.
+func ownMessage(classified kinds.Classified) string {
+	err, ok := classified.(error)
+	if !ok {
+		return ""
+	}
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		if e.Unwrap() != nil {
+			return ""
+		}
+	case interface{ Unwrap() []error }:
+		if len(e.Unwrap()) > 0 {
+			return ""
+		}
+	}
+	return err.Error()
+}
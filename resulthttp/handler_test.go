@@ -0,0 +1,255 @@
+package resulthttp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/azat-dev/go-utils/kinds"
+	"github.com/azat-dev/go-utils/result"
+	"github.com/azat-dev/go-utils/validation"
+)
+
+type user struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func serve(h http.Handler, accept string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func decodeProblem(t *testing.T, rec *httptest.ResponseRecorder) map[string]any {
+	t.Helper()
+	var body map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Expected a JSON body, got %q: %v", rec.Body.String(), err)
+	}
+	return body
+}
+
+func TestHandleOk(t *testing.T) {
+	h := Handle(func(*http.Request) result.Result[user] {
+		return result.Ok(user{ID: 1, Name: "Ann"})
+	})
+
+	t.Run("JSON body with 200", func(t *testing.T) {
+		rec := serve(h, "")
+		if rec.Code != http.StatusOK {
+			t.Errorf("Expected status 200, got %d", rec.Code)
+		}
+		if got := rec.Header().Get("Content-Type"); got != "application/json" {
+			t.Errorf("Expected Content-Type application/json, got %q", got)
+		}
+		if got := rec.Header().Get("X-Content-Type-Options"); got != "nosniff" {
+			t.Errorf("Expected X-Content-Type-Options nosniff, got %q", got)
+		}
+		if got := rec.Header().Get("Vary"); got != "Accept" {
+			t.Errorf("Expected Vary Accept, got %q", got)
+		}
+		if got := rec.Body.String(); got != `{"id":1,"name":"Ann"}` {
+			t.Errorf("Expected user JSON, got %s", got)
+		}
+	})
+
+	t.Run("wildcard Accept", func(t *testing.T) {
+		if rec := serve(h, "text/html, */*;q=0.1"); rec.Code != http.StatusOK {
+			t.Errorf("Expected status 200, got %d", rec.Code)
+		}
+	})
+
+	t.Run("JSON not acceptable", func(t *testing.T) {
+		rec := serve(h, "text/html, application/json;q=0")
+		if rec.Code != http.StatusNotAcceptable {
+			t.Errorf("Expected status 406, got %d", rec.Code)
+		}
+		if got := rec.Header().Get("Content-Type"); got != "application/problem+json" {
+			t.Errorf("Expected problem+json even though not accepted, got %q", got)
+		}
+	})
+
+	t.Run("value that cannot be encoded", func(t *testing.T) {
+		h := Handle(func(*http.Request) result.Result[func()] {
+			return result.Ok(func() {})
+		})
+		if rec := serve(h, ""); rec.Code != http.StatusInternalServerError {
+			t.Errorf("Expected status 500, got %d", rec.Code)
+		}
+	})
+}
+
+func TestHandleErr(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		title  string
+		detail string
+	}{
+		{"internal error hides details", errors.New("db password is hunter2"), 500, "Internal Server Error", ""},
+		{"canceled", fmt.Errorf("load: %w", context.Canceled), 499, "Client Closed Request", "the request was canceled"},
+		{"deadline", context.DeadlineExceeded, 504, "Gateway Timeout", "the request timed out"},
+		{"kind", fmt.Errorf("find: %w", kinds.New(kinds.NotFound, "no user 1")), 404, "Not Found", "no user 1"},
+		{"kind over context", kinds.Wrap(kinds.Unavailable, context.DeadlineExceeded), 503, "Service Unavailable", ""},
+		{
+			"kind wrapping a cause hides it",
+			kinds.Errorf(kinds.Unavailable, "query users: %w", errors.New("dial tcp db-01:5432: connection refused")),
+			503, "Service Unavailable", "",
+		},
+		{"internal kind hides details", kinds.New(kinds.Internal, "nil map in cache"), 500, "Internal Server Error", ""},
+		{"problem", fmt.Errorf("find: %w", &Problem{Status: 404, Detail: "no user 1"}), 404, "Not Found", "no user 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := Handle(func(*http.Request) result.Result[user] { return result.Err[user](tt.err) })
+			rec := serve(h, "application/json")
+			if rec.Code != tt.status {
+				t.Errorf("Expected status %d, got %d", tt.status, rec.Code)
+			}
+			body := decodeProblem(t, rec)
+			if body["status"] != float64(tt.status) || body["title"] != tt.title {
+				t.Errorf("Expected status %d and title %q, got %v", tt.status, tt.title, body)
+			}
+			if detail, _ := body["detail"].(string); detail != tt.detail {
+				t.Errorf("Expected detail %q, got %q", tt.detail, detail)
+			}
+		})
+	}
+}
+
+func TestHandleValidationErrors(t *testing.T) {
+	h := Handle(func(*http.Request) result.Result[user] {
+		return validation.Map2(
+			validation.Field("id", result.Ok(1)),
+			validation.Fail[string](errors.New("must not be empty"), "name"),
+			func(id int, name string) user { return user{ID: id, Name: name} },
+		).ToResult()
+	})
+	rec := serve(h, "")
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status 422, got %d", rec.Code)
+	}
+	body := decodeProblem(t, rec)
+	errs, _ := body["errors"].([]any)
+	if len(errs) != 1 {
+		t.Fatalf("Expected one field error, got %v", body["errors"])
+	}
+	if fieldErr := errs[0].(map[string]any); fieldErr["field"] != "name" || fieldErr["detail"] != "must not be empty" {
+		t.Errorf("Expected name field error, got %v", fieldErr)
+	}
+}
+
+func TestHandlePanic(t *testing.T) {
+	t.Run("recovered as 500", func(t *testing.T) {
+		h := Handle(func(*http.Request) result.Result[user] { panic("boom") })
+		rec := serve(h, "")
+		if rec.Code != http.StatusInternalServerError {
+			t.Errorf("Expected status 500, got %d", rec.Code)
+		}
+		if strings.Contains(rec.Body.String(), "boom") {
+			t.Errorf("Expected panic value to stay out of the response, got %s", rec.Body.String())
+		}
+	})
+
+	t.Run("ErrAbortHandler is re-raised", func(t *testing.T) {
+		h := Handle(func(*http.Request) result.Result[user] { panic(http.ErrAbortHandler) })
+		defer func() {
+			if v := recover(); v != http.ErrAbortHandler {
+				t.Errorf("Expected ErrAbortHandler panic, got %v", v)
+			}
+		}()
+		serve(h, "")
+	})
+}
+
+func TestHandleErrContentNegotiation(t *testing.T) {
+	h := Handle(func(*http.Request) result.Result[user] {
+		return result.Err[user](&Problem{Status: http.StatusNotFound, Detail: "no user 1"})
+	})
+	tests := []struct {
+		accept      string
+		contentType string
+	}{
+		{"", "application/problem+json"},
+		{"application/problem+json", "application/problem+json"},
+		{"application/json", "application/json"},
+		{"application/*", "application/problem+json"},
+		{"text/plain", "text/plain; charset=utf-8"},
+		{"text/plain;q=0.5, application/json", "application/json"},
+		{"image/png", "application/problem+json"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			rec := serve(h, tt.accept)
+			if rec.Code != http.StatusNotFound {
+				t.Errorf("Expected status 404, got %d", rec.Code)
+			}
+			if got := rec.Header().Get("Content-Type"); got != tt.contentType {
+				t.Errorf("Expected Content-Type %q, got %q", tt.contentType, got)
+			}
+		})
+	}
+
+	t.Run("plain text body", func(t *testing.T) {
+		rec := serve(h, "text/plain")
+		if got := rec.Body.String(); got != "Not Found (404): no user 1\n" {
+			t.Errorf("Expected plain text problem, got %q", got)
+		}
+	})
+}
+
+func TestHandleWith(t *testing.T) {
+	errConflict := errors.New("conflict")
+	var logged []string
+	config := Config{
+		ErrorMapper: func(r *http.Request, err error) Problem {
+			if errors.Is(err, errConflict) {
+				return Problem{Type: "https://example.com/probs/conflict", Title: "Conflict", Status: 409, Instance: r.URL.Path}
+			}
+			return DefaultErrorMapper(r, err)
+		},
+		OnError: func(r *http.Request, err error, problem Problem) {
+			logged = append(logged, fmt.Sprintf("%s %d %v", r.URL.Path, problem.Status, err))
+		},
+	}
+	h := HandleWith(config, func(*http.Request) result.Result[user] { return result.Err[user](errConflict) })
+
+	rec := serve(h, "")
+	if rec.Code != http.StatusConflict {
+		t.Errorf("Expected status 409, got %d", rec.Code)
+	}
+	want := `{"type":"https://example.com/probs/conflict","title":"Conflict","status":409,"instance":"/users/1"}`
+	if got := rec.Body.String(); got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+	if len(logged) != 1 || logged[0] != "/users/1 409 conflict" {
+		t.Errorf("Expected OnError to be called once, got %v", logged)
+	}
+}
+
+func TestProblemMarshalJSON(t *testing.T) {
+	p := Problem{
+		Title:      "Out of credit",
+		Status:     403,
+		Extensions: map[string]any{"balance": 30, "accounts": []string{"/a/1"}, "status": "ignored"},
+	}
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"title":"Out of credit","status":403,"accounts":["/a/1"],"balance":30}`
+	if string(data) != want {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+}
@@ -0,0 +1,28 @@
+package resulthttp
+
+import "testing"
+
+func TestNegotiate(t *testing.T) {
+	offers := []string{"application/problem+json", "application/json", "text/plain"}
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{"", "application/problem+json"},
+		{"*/*", "application/problem+json"},
+		{"application/json", "application/json"},
+		{"Application/JSON", "application/json"},
+		{"text/*;q=0.9, application/json;q=0.5", "text/plain"},
+		{"*/*;q=0.1, application/json", "application/json"},
+		{"application/*;q=0.2, application/problem+json;q=0", "application/json"},
+		{"image/png", ""},
+		{"*/*;q=0", ""},
+		{"garbage, text/plain", "text/plain"},
+		{"text/plain;q=bad", "text/plain"},
+	}
+	for _, tt := range tests {
+		if got := negotiate(tt.accept, offers...); got != tt.want {
+			t.Errorf("negotiate(%q) = %q, expected %q", tt.accept, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package resulthttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+)
+
+// StatusClientClosedRequest is the non-standard status used when the client went away before the response was written.
+const StatusClientClosedRequest = 499
+
+var standardMembers = []string{"type", "title", "status", "detail", "instance"}
+
+// Problem is a problem details object as defined by RFC 9457, used as the body of error responses.
+// It implements error, so a handler can return one in an Err to choose the response itself.
+type Problem struct {
+	// Type is a URI identifying the kind of problem. Empty means "about:blank",
+	// in which case Title should be the text of Status.
+	Type string
+	// Title is a short summary of the kind of problem, the same for every occurrence.
+	Title string
+	// Status is the HTTP status code of the response.
+	Status int
+	// Detail explains this occurrence of the problem to the client.
+	Detail string
+	// Instance is a URI identifying this occurrence of the problem.
+	Instance string
+	// Extensions are additional members written next to the standard ones.
+	// Keys that collide with a standard member are ignored.
+	Extensions map[string]any
+}
+
+// Error returns the title and status, followed by the detail if there is one.
+func (p *Problem) Error() string {
+	msg := p.Title
+	if msg == "" {
+		msg = http.StatusText(p.Status)
+	}
+	msg += " (" + strconv.Itoa(p.Status) + ")"
+	if p.Detail != "" {
+		msg += ": " + p.Detail
+	}
+	return msg
+}
+
+// MarshalJSON encodes the standard members that are set, followed by the extensions in key order.
+func (p Problem) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	write := func(key string, value any) error {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		name, _ := json.Marshal(key)
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(raw)
+		return nil
+	}
+
+	standard := []struct {
+		key   string
+		value any
+		set   bool
+	}{
+		{"type", p.Type, p.Type != ""},
+		{"title", p.Title, p.Title != ""},
+		{"status", p.Status, p.Status != 0},
+		{"detail", p.Detail, p.Detail != ""},
+		{"instance", p.Instance, p.Instance != ""},
+	}
+	for _, member := range standard {
+		if member.set {
+			if err := write(member.key, member.value); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(p.Extensions))
+	for key := range p.Extensions {
+		if !slices.Contains(standardMembers, key) {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		if err := write(key, p.Extensions[key]); err != nil {
+			return nil, err
+		}
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
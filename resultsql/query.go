@@ -0,0 +1,82 @@
+package resultsql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/azat-dev/go-utils/optional"
+	"github.com/azat-dev/go-utils/result"
+)
+
+// Querier is the part of *sql.DB, *sql.Tx and *sql.Conn used by the query helpers,
+// so the same code runs inside and outside transactions.
+type Querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// QueryOne runs the query and scans its first row into a T.
+// It returns Ok(None) if there are no rows, and ignores the rows after the first one.
+//
+// A struct T that doesn't implement sql.Scanner (other than time.Time) receives one column per field:
+// the column name is the field's `db` tag, or its lower-cased name without one. Fields tagged `db:"-"`
+// and unexported fields are skipped, and fields of embedded structs are included as if declared in T.
+// The same goes for exported embedded struct pointers, which are allocated when one of their columns is scanned.
+// A column without a field is an error; a field without a column keeps its zero value.
+// Fields of type optional.Optional[U] receive None for NULL.
+//
+// Any other T receives the only column of the row. A NULL scanned into a pointer T also gives Ok(None).
+func QueryOne[T any](ctx context.Context, q Querier, query string, args ...any) (r result.Result[optional.Optional[T]]) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return result.Err[optional.Optional[T]](err)
+	}
+	defer closeRows(rows, &r)
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return result.Err[optional.Optional[T]](err)
+		}
+		return result.Ok(optional.None[T]())
+	}
+	var value T
+	if err := scanRow(rows, &value); err != nil {
+		return result.Err[optional.Optional[T]](err)
+	}
+	return result.Ok(optional.NewFromNullable(value))
+}
+
+// QueryAll runs the query and scans every row into a T, following the rules of QueryOne.
+// No rows gives Ok with an empty slice.
+func QueryAll[T any](ctx context.Context, q Querier, query string, args ...any) (r result.Result[[]T]) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return result.Err[[]T](err)
+	}
+	defer closeRows(rows, &r)
+
+	values := make([]T, 0)
+	for rows.Next() {
+		var value T
+		if err := scanRow(rows, &value); err != nil {
+			return result.Err[[]T](err)
+		}
+		values = append(values, value)
+	}
+	if err := rows.Err(); err != nil {
+		return result.Err[[]T](err)
+	}
+	return result.Ok(values)
+}
+
+// Exec runs a statement that returns no rows.
+func Exec(ctx context.Context, q Querier, query string, args ...any) result.Result[sql.Result] {
+	return result.From(q.ExecContext(ctx, query, args...))
+}
+
+// closeRows closes rows and turns an Ok Result into an Err if closing fails.
+func closeRows[T any](rows *sql.Rows, r *result.Result[T]) {
+	if err := rows.Close(); err != nil && r.IsOk() {
+		*r = result.Err[T](err)
+	}
+}
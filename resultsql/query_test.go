@@ -0,0 +1,354 @@
+package resultsql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/azat-dev/go-utils/internal/fakedb"
+	"github.com/azat-dev/go-utils/optional"
+)
+
+type audit struct {
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type user struct {
+	audit
+	ID       int64
+	Name     string                    `db:"full_name"`
+	Nickname optional.Optional[string] `db:"nickname"`
+	Secret   string                    `db:"-"`
+	internal string
+}
+
+// Profile is exported so that member can embed it through an exported pointer; it embeds itself to check that flattening terminates.
+type Profile struct {
+	Bio string `db:"bio"`
+	*Profile
+}
+
+type member struct {
+	ID int64
+	*Profile
+	*audit
+}
+
+var created = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func usersDB(values ...[]driver.Value) *fakedb.DB {
+	return &fakedb.DB{
+		Query: func(string, []driver.Value) (fakedb.Rows, error) {
+			return fakedb.Rows{Columns: []string{"id", "full_name", "nickname", "created_at"}, Values: values}, nil
+		},
+	}
+}
+
+func TestQueryOne(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("struct with tags and NULL Optional", func(t *testing.T) {
+		fake := usersDB([]driver.Value{int64(1), "Ann Lee", nil, created}, []driver.Value{int64(2), "Bob", "bobby", created})
+		db := fake.Open()
+		defer db.Close()
+
+		u, err := QueryOne[user](ctx, db, "SELECT * FROM users WHERE id = ?", 1).Get()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, ok := u.Get()
+		if !ok {
+			t.Fatal("Expected Some")
+		}
+		if got.ID != 1 || got.Name != "Ann Lee" || got.Nickname.IsSome() || !got.CreatedAt.Equal(created) {
+			t.Errorf("Unexpected user %+v", got)
+		}
+		if log := fake.Log(); !slices.Equal(log, []string{"SELECT * FROM users WHERE id = ?"}) {
+			t.Errorf("Unexpected log %v", log)
+		}
+	})
+
+	t.Run("Optional field with value", func(t *testing.T) {
+		db := usersDB([]driver.Value{int64(2), "Bob", []byte("bobby"), created}).Open()
+		defer db.Close()
+
+		u := QueryOne[user](ctx, db, "SELECT").Unwrap().Unwrap() //resultcheck:ignore unwrap
+		if nickname := u.Nickname.UnwrapOr(""); nickname != "bobby" {
+			t.Errorf("Expected nickname 'bobby', got '%s'", nickname)
+		}
+	})
+
+	t.Run("no rows gives None", func(t *testing.T) {
+		db := usersDB().Open()
+		defer db.Close()
+
+		u, err := QueryOne[user](ctx, db, "SELECT").Get()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if u.IsSome() {
+			t.Error("Expected None")
+		}
+	})
+
+	t.Run("single column into scalar", func(t *testing.T) {
+		fake := &fakedb.DB{Query: func(string, []driver.Value) (fakedb.Rows, error) {
+			return fakedb.Rows{Columns: []string{"count"}, Values: [][]driver.Value{{int64(3)}}}, nil
+		}}
+		db := fake.Open()
+		defer db.Close()
+
+		count, err := QueryOne[int](ctx, db, "SELECT count(*) FROM users").Get()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count.UnwrapOr(0) != 3 {
+			t.Errorf("Expected 3, got %v", count)
+		}
+	})
+
+	t.Run("NULL into pointer gives None", func(t *testing.T) {
+		fake := &fakedb.DB{Query: func(string, []driver.Value) (fakedb.Rows, error) {
+			return fakedb.Rows{Columns: []string{"name"}, Values: [][]driver.Value{{nil}}}, nil
+		}}
+		db := fake.Open()
+		defer db.Close()
+
+		name, err := QueryOne[*string](ctx, db, "SELECT name").Get()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if name.IsSome() {
+			t.Error("Expected None")
+		}
+	})
+
+	t.Run("Optional as scalar", func(t *testing.T) {
+		fake := &fakedb.DB{Query: func(string, []driver.Value) (fakedb.Rows, error) {
+			return fakedb.Rows{Columns: []string{"name"}, Values: [][]driver.Value{{nil}}}, nil
+		}}
+		db := fake.Open()
+		defer db.Close()
+
+		name, err := QueryOne[optional.Optional[string]](ctx, db, "SELECT name").Get()
+		if err != nil {
+			t.Fatal(err)
+		}
+		row, ok := name.Get()
+		if !ok || row.IsSome() {
+			t.Errorf("Expected Some(None), got %v", name)
+		}
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		errDown := errors.New("database is down")
+		fake := &fakedb.DB{Query: func(string, []driver.Value) (fakedb.Rows, error) { return fakedb.Rows{}, errDown }}
+		db := fake.Open()
+		defer db.Close()
+
+		if _, err := QueryOne[user](ctx, db, "SELECT").Get(); !errors.Is(err, errDown) {
+			t.Errorf("Expected errDown, got %v", err)
+		}
+	})
+}
+
+func TestEmbeddedPointers(t *testing.T) {
+	ctx := context.Background()
+	query := func(columns ...string) func(string, []driver.Value) (fakedb.Rows, error) {
+		return func(string, []driver.Value) (fakedb.Rows, error) {
+			values := map[string]driver.Value{"id": int64(1), "bio": "hello"}
+			row := make([]driver.Value, len(columns))
+			for i, column := range columns {
+				row[i] = values[column]
+			}
+			return fakedb.Rows{Columns: columns, Values: [][]driver.Value{row}}, nil
+		}
+	}
+
+	t.Run("pointer is allocated for its columns", func(t *testing.T) {
+		db := (&fakedb.DB{Query: query("id", "bio")}).Open()
+		defer db.Close()
+
+		m, err := QueryOne[member](ctx, db, "SELECT").Get()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := m.UnwrapOr(member{})
+		if got.ID != 1 || got.Profile == nil || got.Bio != "hello" {
+			t.Errorf("Unexpected member %+v", got)
+		}
+		if got.Profile != nil && got.Profile.Profile != nil {
+			t.Error("Expected the self-embedded pointer to stay nil")
+		}
+	})
+
+	t.Run("pointer without columns stays nil", func(t *testing.T) {
+		db := (&fakedb.DB{Query: query("id")}).Open()
+		defer db.Close()
+
+		m, err := QueryOne[member](ctx, db, "SELECT").Get()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := m.UnwrapOr(member{}); got.ID != 1 || got.Profile != nil {
+			t.Errorf("Unexpected member %+v", got)
+		}
+	})
+
+	t.Run("unexported pointer is skipped", func(t *testing.T) {
+		db := (&fakedb.DB{Query: query("created_at")}).Open()
+		defer db.Close()
+
+		_, err := QueryOne[member](ctx, db, "SELECT").Get()
+		if err == nil || !strings.Contains(err.Error(), `column "created_at" has no matching field`) {
+			t.Errorf("Expected unknown column error, got %v", err)
+		}
+	})
+}
+
+func TestQueryAll(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("every row", func(t *testing.T) {
+		db := usersDB([]driver.Value{int64(1), "Ann", nil, created}, []driver.Value{int64(2), "Bob", "bobby", created}).Open()
+		defer db.Close()
+
+		users, err := QueryAll[user](ctx, db, "SELECT").Get()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(users) != 2 || users[0].Name != "Ann" || users[1].Nickname.UnwrapOr("") != "bobby" {
+			t.Errorf("Unexpected users %+v", users)
+		}
+	})
+
+	t.Run("no rows gives empty slice", func(t *testing.T) {
+		db := usersDB().Open()
+		defer db.Close()
+
+		users, err := QueryAll[user](ctx, db, "SELECT").Get()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if users == nil || len(users) != 0 {
+			t.Errorf("Expected empty non-nil slice, got %#v", users)
+		}
+	})
+}
+
+func TestScanErrors(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name    string
+		columns []string
+		values  []driver.Value
+		scan    func(Querier) error
+		msg     string
+	}{
+		{
+			name:    "unknown column",
+			columns: []string{"id", "email"},
+			values:  []driver.Value{int64(1), "a@b.c"},
+			scan:    func(q Querier) error { _, err := QueryOne[user](ctx, q, "SELECT").Get(); return err },
+			msg:     `column "email" has no matching field in resultsql.user`,
+		},
+		{
+			name:    "skipped field",
+			columns: []string{"secret"},
+			values:  []driver.Value{"x"},
+			scan:    func(q Querier) error { _, err := QueryOne[user](ctx, q, "SELECT").Get(); return err },
+			msg:     `column "secret" has no matching field`,
+		},
+		{
+			name:    "too many columns for scalar",
+			columns: []string{"a", "b"},
+			values:  []driver.Value{int64(1), int64(2)},
+			scan:    func(q Querier) error { _, err := QueryAll[int](ctx, q, "SELECT").Get(); return err },
+			msg:     "query returned 2 columns, scanning into int needs 1",
+		},
+		{
+			name:    "conversion error",
+			columns: []string{"id"},
+			values:  []driver.Value{"abc"},
+			scan:    func(q Querier) error { _, err := QueryOne[user](ctx, q, "SELECT").Get(); return err },
+			msg:     "converting",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakedb.DB{Query: func(string, []driver.Value) (fakedb.Rows, error) {
+				return fakedb.Rows{Columns: tt.columns, Values: [][]driver.Value{tt.values}}, nil
+			}}
+			db := fake.Open()
+			defer db.Close()
+
+			if err := tt.scan(db); err == nil || !strings.Contains(err.Error(), tt.msg) {
+				t.Errorf("Expected error containing %q, got %v", tt.msg, err)
+			}
+		})
+	}
+}
+
+func TestExec(t *testing.T) {
+	ctx := context.Background()
+	var gotArgs []driver.Value
+	fake := &fakedb.DB{Exec: func(_ string, args []driver.Value) error {
+		gotArgs = args
+		return nil
+	}}
+	db := fake.Open()
+	defer db.Close()
+
+	res, err := Exec(ctx, db, "UPDATE users SET nickname = ? WHERE id = ?", optional.None[string](), 1).Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, _ := res.RowsAffected(); n != 1 {
+		t.Errorf("Expected 1 row affected, got %d", n)
+	}
+	if len(gotArgs) != 2 || gotArgs[0] != nil || gotArgs[1] != int64(1) {
+		t.Errorf("Expected None to be passed as NULL, got %v", gotArgs)
+	}
+
+	errLocked := errors.New("table is locked")
+	fake.Exec = func(string, []driver.Value) error { return errLocked }
+	if _, err := Exec(ctx, db, "DELETE FROM users").Get(); !errors.Is(err, errLocked) {
+		t.Errorf("Expected errLocked, got %v", err)
+	}
+}
+
+func TestQuerierImplementations(t *testing.T) {
+	ctx := context.Background()
+	fake := usersDB([]driver.Value{int64(1), "Ann", nil, created})
+	db := fake.Open()
+	defer db.Close()
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if QueryOne[user](ctx, conn, "SELECT conn").IsErr() {
+		t.Error("Expected query through *sql.Conn to succeed")
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if QueryOne[user](ctx, tx, "SELECT tx").IsErr() {
+		t.Error("Expected query through *sql.Tx to succeed")
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"SELECT conn", "BEGIN", "SELECT tx", "COMMIT"}
+	if log := fake.Log(); !slices.Equal(log, want) {
+		t.Errorf("Expected log %v, got %v", want, log)
+	}
+}
@@ -0,0 +1,137 @@
+package resultsql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// errNoColumns is returned when a query used for scanning returns no columns at all.
+var errNoColumns = errors.New("resultsql: query returned no columns")
+
+var (
+	scannerType = reflect.TypeFor[sql.Scanner]()
+	timeType    = reflect.TypeFor[time.Time]()
+)
+
+// fieldCache maps struct types to their column-to-field index map.
+var fieldCache sync.Map
+
+// scanRow scans the current row into *dest, following the rules described on QueryOne.
+func scanRow[T any](rows *sql.Rows, dest *T) error {
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	if len(columns) == 0 {
+		return errNoColumns
+	}
+
+	v := reflect.ValueOf(dest).Elem()
+	if !isStruct(v.Type()) {
+		if len(columns) != 1 {
+			return fmt.Errorf("resultsql: query returned %d columns, scanning into %s needs 1", len(columns), v.Type())
+		}
+		return rows.Scan(dest)
+	}
+
+	fields := structFields(v.Type())
+	dests := make([]any, len(columns))
+	for i, column := range columns {
+		index, ok := fields[strings.ToLower(column)]
+		if !ok {
+			return fmt.Errorf("resultsql: column %q has no matching field in %s", column, v.Type())
+		}
+		dests[i] = fieldByIndex(v, index).Addr().Interface()
+	}
+	return rows.Scan(dests...)
+}
+
+// isStruct reports whether t is scanned field by field rather than as a single value.
+func isStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t != timeType && !reflect.PointerTo(t).Implements(scannerType)
+}
+
+// structFields returns the field indexes of t by lower-cased column name.
+func structFields(t reflect.Type) map[string][]int {
+	if cached, ok := fieldCache.Load(t); ok {
+		return cached.(map[string][]int)
+	}
+	fields := make(map[string][]int)
+	depths := make(map[string]int)
+	collectFields(t, nil, fields, depths, map[reflect.Type]bool{t: true})
+	cached, _ := fieldCache.LoadOrStore(t, fields)
+	return cached.(map[string][]int)
+}
+
+// collectFields adds the fields of t to fields. When names collide, the shallower field wins, as in encoding/json.
+// Embedded structs and exported embedded struct pointers are flattened; visiting holds the struct types
+// on the current path, so a type embedding a pointer to itself isn't walked forever.
+func collectFields(t reflect.Type, parent []int, fields map[string][]int, depths map[string]int, visiting map[reflect.Type]bool) {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag := f.Tag.Get("db")
+		if tag == "-" {
+			continue
+		}
+		index := append(append([]int(nil), parent...), i)
+		if embedded := embeddedStruct(f); embedded != nil && tag == "" {
+			if !visiting[embedded] {
+				visiting[embedded] = true
+				collectFields(embedded, index, fields, depths, visiting)
+				delete(visiting, embedded)
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		name := tag
+		if name == "" {
+			name = f.Name
+		}
+		name = strings.ToLower(name)
+		if depth, ok := depths[name]; ok && depth <= len(parent) {
+			continue
+		}
+		fields[name] = index
+		depths[name] = len(parent)
+	}
+}
+
+// embeddedStruct returns the struct type whose fields f contributes to its parent, or nil if f isn't flattened.
+// An unexported embedded pointer is skipped, because a nil one can't be allocated through reflection.
+func embeddedStruct(f reflect.StructField) reflect.Type {
+	if !f.Anonymous {
+		return nil
+	}
+	t := f.Type
+	if t.Kind() == reflect.Pointer {
+		if !f.IsExported() {
+			return nil
+		}
+		t = t.Elem()
+	}
+	if !isStruct(t) {
+		return nil
+	}
+	return t
+}
+
+// fieldByIndex is like reflect.Value.FieldByIndex, but allocates nil embedded struct pointers on the way.
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
@@ -0,0 +1,163 @@
+package resultsql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/azat-dev/go-utils/result"
+	"github.com/azat-dev/go-utils/retry"
+)
+
+// TxBeginner is the part of *sql.DB and *sql.Conn used by WithTx.
+type TxBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// TxOptions controls how WithTx runs a transaction.
+type TxOptions struct {
+	// Tx is passed to BeginTx. Nil uses the driver defaults.
+	Tx *sql.TxOptions
+	// IsSerializationFailure reports whether an error means the transaction lost a conflict
+	// with a concurrent one and can be run again, such as PostgreSQL's SQLSTATE 40001.
+	// Nil disables retries.
+	IsSerializationFailure func(error) bool
+	// Retry controls how often and when a failed transaction is run again.
+	// Its Retryable is replaced by IsSerializationFailure. The zero Policy means retry.DefaultPolicy().
+	Retry retry.Policy
+}
+
+// DefaultTxOptions returns TxOptions with driver defaults, no retries, and retry.DefaultPolicy
+// ready to be used once IsSerializationFailure is set.
+func DefaultTxOptions() TxOptions {
+	return TxOptions{Tx: nil, IsSerializationFailure: nil, Retry: retry.DefaultPolicy()}
+}
+
+// txState is stored in the context of a running transaction, so nested WithTx calls can find it.
+type txState struct {
+	db         TxBeginner
+	tx         *sql.Tx
+	savepoints atomic.Int64
+}
+
+type txKey struct{}
+
+// WithTx runs f in a transaction on db and commits it if f returns Ok.
+// If f returns Err the transaction is rolled back, and a failed rollback is joined to f's error.
+// If f panics the transaction is rolled back and the panic continues.
+//
+// The context passed to f carries the transaction: a WithTx call on the same db with that context
+// runs in a savepoint of the outer transaction instead of a new one, so only its own work is undone on Err.
+// Nested calls ignore opts. Savepoints nest, so nested calls on the same transaction must not run
+// concurrently: rolling back one savepoint would also undo the work of the others started after it.
+//
+// With opts.IsSerializationFailure set, an outermost transaction failing with such an error,
+// from f or from the commit, is rolled back and run again following opts.Retry; the Err then holds
+// a *retry.Error. Any other error of the first attempt is returned as is, as without retries.
+// f must not have side effects outside the transaction in that case.
+func WithTx[T any](
+	ctx context.Context,
+	db TxBeginner,
+	opts TxOptions,
+	f func(ctx context.Context, tx *sql.Tx) result.Result[T],
+) result.Result[T] {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok && state.db == db {
+		return withSavepoint(ctx, state, f)
+	}
+	if opts.IsSerializationFailure == nil {
+		return withTx(ctx, db, opts.Tx, f)
+	}
+	policy := opts.Retry
+	if isZeroPolicy(policy) {
+		policy = retry.DefaultPolicy()
+	}
+	policy.Retryable = opts.IsSerializationFailure
+	r := retry.Do(ctx, policy, func(ctx context.Context) result.Result[T] {
+		return withTx(ctx, db, opts.Tx, f)
+	})
+	// A first attempt failing for another reason was never retried, so its error is returned as withTx gave it.
+	if retryErr, ok := result.ErrAs[*retry.Error](r).Get(); ok && retryErr.Attempts == 1 && len(retryErr.Errors) == 1 {
+		if err := retryErr.Errors[0]; !opts.IsSerializationFailure(err) {
+			return result.Err[T](err)
+		}
+	}
+	return r
+}
+
+func withTx[T any](
+	ctx context.Context,
+	db TxBeginner,
+	txOpts *sql.TxOptions,
+	f func(context.Context, *sql.Tx) result.Result[T],
+) result.Result[T] {
+	tx, err := db.BeginTx(ctx, txOpts)
+	if err != nil {
+		return result.Err[T](err)
+	}
+
+	// Roll back while a panic unwinds, without recovering it.
+	finished := false
+	defer func() {
+		if !finished {
+			_ = tx.Rollback()
+		}
+	}()
+
+	r := f(context.WithValue(ctx, txKey{}, &txState{db: db, tx: tx, savepoints: atomic.Int64{}}), tx)
+	finished = true
+	if _, err := r.Get(); err != nil {
+		return result.Err[T](joinRollback(err, tx.Rollback()))
+	}
+	if err := tx.Commit(); err != nil {
+		return result.Err[T](err)
+	}
+	return r
+}
+
+func withSavepoint[T any](
+	ctx context.Context,
+	state *txState,
+	f func(context.Context, *sql.Tx) result.Result[T],
+) result.Result[T] {
+	name := fmt.Sprintf("sp_%d", state.savepoints.Add(1))
+	if _, err := state.tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return result.Err[T](err)
+	}
+
+	rollback := func() error {
+		_, err := state.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name)
+		return err
+	}
+	finished := false
+	defer func() {
+		if !finished {
+			_ = rollback()
+		}
+	}()
+
+	r := f(ctx, state.tx)
+	finished = true
+	if _, err := r.Get(); err != nil {
+		return result.Err[T](joinRollback(err, rollback()))
+	}
+	if _, err := state.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name); err != nil {
+		return result.Err[T](err)
+	}
+	return r
+}
+
+// isZeroPolicy reports whether p is the zero retry.Policy, which TxOptions literals get when they omit Retry.
+func isZeroPolicy(p retry.Policy) bool {
+	return p.MaxAttempts == 0 && p.Backoff == nil && p.MaxElapsed == 0 && p.Retryable == nil && p.Clock == nil
+}
+
+// joinRollback adds a rollback failure to the error that caused the rollback.
+// sql.ErrTxDone is ignored: the transaction was already rolled back, for example because ctx was canceled.
+func joinRollback(err, rollbackErr error) error {
+	if rollbackErr == nil || errors.Is(rollbackErr, sql.ErrTxDone) {
+		return err
+	}
+	return errors.Join(err, fmt.Errorf("resultsql: rollback: %w", rollbackErr))
+}
@@ -0,0 +1,318 @@
+package resultsql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/azat-dev/go-utils/internal/fakedb"
+	"github.com/azat-dev/go-utils/result"
+	"github.com/azat-dev/go-utils/retry"
+)
+
+var errSerialization = errors.New("could not serialize access due to concurrent update")
+
+func insert(ctx context.Context, tx *sql.Tx) result.Result[int] {
+	return result.MapResult(Exec(ctx, tx, "INSERT"), func(sql.Result) int { return 1 })
+}
+
+func checkLog(t *testing.T, fake *fakedb.DB, want ...string) {
+	t.Helper()
+	if log := fake.Log(); !slices.Equal(log, want) {
+		t.Errorf("Expected log %q, got %q", want, log)
+	}
+}
+
+func TestWithTx(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("commit on Ok", func(t *testing.T) {
+		fake := &fakedb.DB{}
+		db := fake.Open()
+		defer db.Close()
+
+		n, err := WithTx(ctx, db, DefaultTxOptions(), insert).Get()
+		if err != nil || n != 1 {
+			t.Errorf("Expected Ok(1), got %d, %v", n, err)
+		}
+		checkLog(t, fake, "BEGIN", "INSERT", "COMMIT")
+	})
+
+	t.Run("rollback on Err", func(t *testing.T) {
+		fake := &fakedb.DB{}
+		db := fake.Open()
+		defer db.Close()
+
+		errInvalid := errors.New("invalid")
+		r := WithTx(ctx, db, DefaultTxOptions(), func(ctx context.Context, tx *sql.Tx) result.Result[int] {
+			_ = insert(ctx, tx)
+			return result.Err[int](errInvalid)
+		})
+		if _, err := r.Get(); err != errInvalid {
+			t.Errorf("Expected errInvalid unchanged, got %v", err)
+		}
+		checkLog(t, fake, "BEGIN", "INSERT", "ROLLBACK")
+	})
+
+	t.Run("rollback error is joined", func(t *testing.T) {
+		errConnLost := errors.New("connection lost")
+		fake := &fakedb.DB{Rollback: func() error { return errConnLost }}
+		db := fake.Open()
+		defer db.Close()
+
+		errInvalid := errors.New("invalid")
+		_, err := WithTx(ctx, db, DefaultTxOptions(), func(context.Context, *sql.Tx) result.Result[int] {
+			return result.Err[int](errInvalid)
+		}).Get()
+		if !errors.Is(err, errInvalid) || !errors.Is(err, errConnLost) {
+			t.Errorf("Expected both errors, got %v", err)
+		}
+		if !strings.Contains(err.Error(), "resultsql: rollback: connection lost") {
+			t.Errorf("Expected rollback error in message, got %q", err)
+		}
+	})
+
+	t.Run("commit error", func(t *testing.T) {
+		errCommit := errors.New("disk full")
+		fake := &fakedb.DB{Commit: func() error { return errCommit }}
+		db := fake.Open()
+		defer db.Close()
+
+		if _, err := WithTx(ctx, db, DefaultTxOptions(), insert).Get(); !errors.Is(err, errCommit) {
+			t.Errorf("Expected commit error, got %v", err)
+		}
+	})
+
+	t.Run("begin error and options", func(t *testing.T) {
+		errBegin := errors.New("too many connections")
+		var gotOpts driver.TxOptions
+		fake := &fakedb.DB{Begin: func(opts driver.TxOptions) error {
+			gotOpts = opts
+			return errBegin
+		}}
+		db := fake.Open()
+		defer db.Close()
+
+		opts := DefaultTxOptions()
+		opts.Tx = &sql.TxOptions{Isolation: sql.LevelSerializable, ReadOnly: true}
+		if _, err := WithTx(ctx, db, opts, insert).Get(); !errors.Is(err, errBegin) {
+			t.Errorf("Expected begin error, got %v", err)
+		}
+		if gotOpts.Isolation != driver.IsolationLevel(sql.LevelSerializable) || !gotOpts.ReadOnly {
+			t.Errorf("Expected options to reach the driver, got %+v", gotOpts)
+		}
+	})
+
+	t.Run("rollback and re-raise on panic", func(t *testing.T) {
+		fake := &fakedb.DB{}
+		db := fake.Open()
+		defer db.Close()
+
+		defer func() {
+			if v := recover(); v != "boom" {
+				t.Errorf("Expected panic 'boom', got %v", v)
+			}
+			checkLog(t, fake, "BEGIN", "INSERT", "ROLLBACK")
+		}()
+		_ = WithTx(ctx, db, DefaultTxOptions(), func(ctx context.Context, tx *sql.Tx) result.Result[int] {
+			_ = insert(ctx, tx)
+			panic("boom")
+		})
+	})
+}
+
+func TestWithTxRetry(t *testing.T) {
+	ctx := context.Background()
+	opts := TxOptions{
+		Tx:                     nil,
+		IsSerializationFailure: func(err error) bool { return errors.Is(err, errSerialization) },
+		Retry:                  retry.Policy{MaxAttempts: 3},
+	}
+
+	t.Run("serialization failure from f", func(t *testing.T) {
+		fake := &fakedb.DB{}
+		db := fake.Open()
+		defer db.Close()
+
+		attempts := 0
+		n, err := WithTx(ctx, db, opts, func(ctx context.Context, tx *sql.Tx) result.Result[int] {
+			attempts++
+			if attempts == 1 {
+				return result.Err[int](errSerialization)
+			}
+			return insert(ctx, tx)
+		}).Get()
+		if err != nil || n != 1 {
+			t.Errorf("Expected Ok(1), got %d, %v", n, err)
+		}
+		checkLog(t, fake, "BEGIN", "ROLLBACK", "BEGIN", "INSERT", "COMMIT")
+	})
+
+	t.Run("serialization failure from commit", func(t *testing.T) {
+		commits := 0
+		fake := &fakedb.DB{Commit: func() error {
+			commits++
+			if commits < 3 {
+				return errSerialization
+			}
+			return nil
+		}}
+		db := fake.Open()
+		defer db.Close()
+
+		if _, err := WithTx(ctx, db, opts, insert).Get(); err != nil {
+			t.Errorf("Expected Ok after retries, got %v", err)
+		}
+		checkLog(t, fake, "BEGIN", "INSERT", "COMMIT", "BEGIN", "INSERT", "COMMIT", "BEGIN", "INSERT", "COMMIT")
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		fake := &fakedb.DB{Commit: func() error { return errSerialization }}
+		db := fake.Open()
+		defer db.Close()
+
+		_, err := WithTx(ctx, db, opts, insert).Get()
+		var retryErr *retry.Error
+		if !errors.As(err, &retryErr) || retryErr.Attempts != 3 {
+			t.Errorf("Expected *retry.Error after 3 attempts, got %v", err)
+		}
+	})
+
+	t.Run("zero Retry uses the default policy", func(t *testing.T) {
+		fake := &fakedb.DB{Commit: func() error { return errSerialization }}
+		db := fake.Open()
+		defer db.Close()
+
+		defaults := TxOptions{Tx: nil, IsSerializationFailure: opts.IsSerializationFailure, Retry: retry.Policy{}}
+		_, err := WithTx(ctx, db, defaults, insert).Get()
+		var retryErr *retry.Error
+		if !errors.As(err, &retryErr) || retryErr.Attempts != retry.DefaultPolicy().MaxAttempts {
+			t.Errorf("Expected *retry.Error after %d attempts, got %v", retry.DefaultPolicy().MaxAttempts, err)
+		}
+	})
+
+	t.Run("other errors are not retried", func(t *testing.T) {
+		fake := &fakedb.DB{}
+		db := fake.Open()
+		defer db.Close()
+
+		errInvalid := errors.New("invalid")
+		attempts := 0
+		_, err := WithTx(ctx, db, opts, func(context.Context, *sql.Tx) result.Result[int] {
+			attempts++
+			return result.Err[int](errInvalid)
+		}).Get()
+		if err != errInvalid || attempts != 1 {
+			t.Errorf("Expected one attempt failing with errInvalid itself, got %d attempts and %v", attempts, err)
+		}
+	})
+
+	t.Run("single serialization failure stays wrapped", func(t *testing.T) {
+		fake := &fakedb.DB{Commit: func() error { return errSerialization }}
+		db := fake.Open()
+		defer db.Close()
+
+		once := opts
+		once.Retry = retry.Policy{MaxAttempts: 1}
+		_, err := WithTx(ctx, db, once, insert).Get()
+		var retryErr *retry.Error
+		if !errors.As(err, &retryErr) || retryErr.Attempts != 1 {
+			t.Errorf("Expected *retry.Error after 1 attempt, got %v", err)
+		}
+	})
+}
+
+func TestWithTxNested(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("savepoints", func(t *testing.T) {
+		fake := &fakedb.DB{}
+		db := fake.Open()
+		defer db.Close()
+
+		errInner := errors.New("inner failed")
+		r := WithTx(ctx, db, DefaultTxOptions(), func(ctx context.Context, outer *sql.Tx) result.Result[int] {
+			ok := WithTx(ctx, db, DefaultTxOptions(), func(ctx context.Context, tx *sql.Tx) result.Result[int] {
+				if tx != outer {
+					t.Error("Expected nested call to share the outer transaction")
+				}
+				return insert(ctx, tx)
+			})
+			failed := WithTx(ctx, db, DefaultTxOptions(), func(ctx context.Context, tx *sql.Tx) result.Result[int] {
+				_ = insert(ctx, tx)
+				return result.Err[int](errInner)
+			})
+			if !failed.ErrIs(errInner) {
+				t.Errorf("Expected inner error, got %v", failed)
+			}
+			return ok
+		})
+		if r.IsErr() {
+			t.Errorf("Expected outer Ok, got %v", r)
+		}
+		checkLog(t, fake,
+			"BEGIN",
+			"SAVEPOINT sp_1", "INSERT", "RELEASE SAVEPOINT sp_1",
+			"SAVEPOINT sp_2", "INSERT", "ROLLBACK TO SAVEPOINT sp_2",
+			"COMMIT")
+	})
+
+	t.Run("panic rolls back savepoint and transaction", func(t *testing.T) {
+		fake := &fakedb.DB{}
+		db := fake.Open()
+		defer db.Close()
+
+		defer func() {
+			if v := recover(); v != "boom" {
+				t.Errorf("Expected panic 'boom', got %v", v)
+			}
+			checkLog(t, fake, "BEGIN", "SAVEPOINT sp_1", "ROLLBACK TO SAVEPOINT sp_1", "ROLLBACK")
+		}()
+		_ = WithTx(ctx, db, DefaultTxOptions(), func(ctx context.Context, _ *sql.Tx) result.Result[int] {
+			return WithTx(ctx, db, DefaultTxOptions(), func(context.Context, *sql.Tx) result.Result[int] {
+				panic("boom")
+			})
+		})
+	})
+
+	t.Run("savepoint rollback error is joined", func(t *testing.T) {
+		errBroken := errors.New("savepoint does not exist")
+		fake := &fakedb.DB{Exec: func(query string, _ []driver.Value) error {
+			if strings.HasPrefix(query, "ROLLBACK TO") {
+				return errBroken
+			}
+			return nil
+		}}
+		db := fake.Open()
+		defer db.Close()
+
+		errInner := errors.New("inner failed")
+		_ = WithTx(ctx, db, DefaultTxOptions(), func(ctx context.Context, _ *sql.Tx) result.Result[int] {
+			_, err := WithTx(ctx, db, DefaultTxOptions(), func(context.Context, *sql.Tx) result.Result[int] {
+				return result.Err[int](errInner)
+			}).Get()
+			if !errors.Is(err, errInner) || !errors.Is(err, errBroken) {
+				t.Errorf("Expected both errors, got %v", err)
+			}
+			return result.Ok(0)
+		})
+	})
+
+	t.Run("other database starts its own transaction", func(t *testing.T) {
+		fakeA, fakeB := &fakedb.DB{}, &fakedb.DB{}
+		dbA, dbB := fakeA.Open(), fakeB.Open()
+		defer dbA.Close()
+		defer dbB.Close()
+
+		_ = WithTx(ctx, dbA, DefaultTxOptions(), func(ctx context.Context, tx *sql.Tx) result.Result[int] {
+			_ = WithTx(ctx, dbB, DefaultTxOptions(), insert)
+			return insert(ctx, tx)
+		})
+		checkLog(t, fakeA, "BEGIN", "INSERT", "COMMIT")
+		checkLog(t, fakeB, "BEGIN", "INSERT", "COMMIT")
+	})
+}
@@ -0,0 +1,45 @@
+package retry
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// Backoff computes the delay before the next attempt.
+// attempt is the number of the attempt that just failed, starting at 1,
+// and previous is the delay used before it (zero after the first attempt).
+type Backoff func(attempt int, previous time.Duration) time.Duration
+
+// Constant waits the same delay before every retry.
+func Constant(delay time.Duration) Backoff {
+	return func(int, time.Duration) time.Duration {
+		return delay
+	}
+}
+
+// Exponential doubles the delay after every attempt, starting at base and never exceeding limit.
+func Exponential(base, limit time.Duration) Backoff {
+	return func(attempt int, _ time.Duration) time.Duration {
+		delay := base
+		for i := 1; i < attempt; i++ {
+			delay *= 2
+			if delay >= limit || delay <= 0 {
+				return limit
+			}
+		}
+		return min(delay, limit)
+	}
+}
+
+// DecorrelatedJitter picks a random delay between base and three times the previous delay,
+// never exceeding limit. It spreads retries of many clients better than plain exponential backoff.
+func DecorrelatedJitter(base, limit time.Duration) Backoff {
+	return func(_ int, previous time.Duration) time.Duration {
+		upper := max(previous*3, base)
+		delay := base
+		if upper > base {
+			delay += time.Duration(rand.Int64N(int64(upper - base)))
+		}
+		return min(delay, limit)
+	}
+}
@@ -0,0 +1,54 @@
+package retry
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConstant(t *testing.T) {
+	b := Constant(time.Second)
+	for attempt := 1; attempt <= 3; attempt++ {
+		if d := b(attempt, time.Minute); d != time.Second {
+			t.Errorf("Expected 1s for attempt %d, got %v", attempt, d)
+		}
+	}
+}
+
+func TestExponential(t *testing.T) {
+	b := Exponential(100*time.Millisecond, time.Second)
+	expected := []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+		time.Second,
+		time.Second,
+	}
+	for i, want := range expected {
+		if d := b(i+1, 0); d != want {
+			t.Errorf("Expected %v for attempt %d, got %v", want, i+1, d)
+		}
+	}
+	if d := b(1000, 0); d != time.Second {
+		t.Errorf("Expected large attempts to be capped, got %v", d)
+	}
+}
+
+func TestDecorrelatedJitter(t *testing.T) {
+	base := 10 * time.Millisecond
+	limit := time.Second
+	b := DecorrelatedJitter(base, limit)
+
+	if d := b(1, 0); d != base {
+		t.Errorf("Expected first delay to be base, got %v", d)
+	}
+
+	previous := base
+	for attempt := 2; attempt < 100; attempt++ {
+		d := b(attempt, previous)
+		if d < base || d > limit || d > max(previous*3, base) {
+			t.Fatalf("Delay %v out of range for previous %v", d, previous)
+		}
+		previous = d
+	}
+}
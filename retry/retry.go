@@ -0,0 +1,136 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/azat-dev/go-utils/clock"
+	"github.com/azat-dev/go-utils/result"
+)
+
+// ErrMaxElapsed is added to the attempt errors when the next delay would exceed Policy.MaxElapsed.
+var ErrMaxElapsed = errors.New("retry: max elapsed time exceeded")
+
+// Unlimited is the Policy.MaxAttempts value that keeps retrying until another limit stops Do or ctx is done.
+const Unlimited = -1
+
+// Policy controls how Do repeats a failing operation.
+type Policy struct {
+	// MaxAttempts is the total number of attempts, including the first one. Unlimited means no limit,
+	// and any other value below 1, including zero, means a single attempt.
+	MaxAttempts int
+	// Backoff computes the delay between attempts. Nil means retrying immediately.
+	Backoff Backoff
+	// MaxElapsed stops retrying when the next attempt would start later than this after the first one.
+	// Zero means no limit.
+	MaxElapsed time.Duration
+	// Retryable reports whether an error is worth another attempt. Nil means every error is retryable.
+	Retryable func(error) bool
+	// Clock is used to wait between attempts and to measure elapsed time. Nil means clock.Real().
+	Clock clock.Clock
+	// MaxErrors bounds the number of errors kept in Error.Errors, dropping the oldest ones,
+	// so unlimited retries don't grow it without bound. Zero or less means every error is kept.
+	MaxErrors int
+}
+
+// DefaultPolicy returns a Policy with 3 attempts and exponential backoff from 100ms up to 2s.
+func DefaultPolicy() Policy {
+	return Policy{
+		MaxAttempts: 3,
+		Backoff:     Exponential(100*time.Millisecond, 2*time.Second),
+		MaxElapsed:  0,
+		Retryable:   nil,
+		Clock:       nil,
+		MaxErrors:   0,
+	}
+}
+
+// Error is stored in the Err Result returned by Do when the operation never succeeded.
+// Errors holds the error of every attempt in order, followed by the reason retrying stopped
+// when it was not the operation itself (context cancellation or ErrMaxElapsed).
+// When Policy.MaxErrors is set, only the last MaxErrors of these are kept and Dropped counts the others.
+type Error struct {
+	Attempts int
+	Errors   []error
+	Dropped  int
+}
+
+// Error returns a message with the number of attempts and the last error.
+func (e *Error) Error() string {
+	return fmt.Sprintf("retry: giving up after %d attempt(s): %v", e.Attempts, e.Errors[len(e.Errors)-1])
+}
+
+// Unwrap returns the errors of all attempts, so errors.Is and errors.As can match any of them.
+func (e *Error) Unwrap() []error {
+	return e.Errors
+}
+
+// Do calls op until it returns Ok or the policy gives up, and returns the last Result.
+// The context passed to op is ctx; Do stops waiting between attempts as soon as ctx is done.
+// When giving up, the Err holds an *Error wrapping every attempt's error, up to Policy.MaxErrors.
+func Do[T any](ctx context.Context, policy Policy, op func(context.Context) result.Result[T]) result.Result[T] {
+	clk := policy.Clock
+	if clk == nil {
+		clk = clock.Real()
+	}
+	start := clk.Now()
+
+	errs := &errorList{max: policy.MaxErrors}
+	var delay time.Duration
+	for attempt := 1; ; attempt++ {
+		r := op(ctx)
+		if r.IsOk() {
+			return r
+		}
+		err := r.UnwrapErr()
+		errs.add(err)
+
+		if policy.Retryable != nil && !policy.Retryable(err) {
+			return giveUp[T](attempt, errs)
+		}
+		if policy.MaxAttempts != Unlimited && attempt >= policy.MaxAttempts {
+			return giveUp[T](attempt, errs)
+		}
+		if policy.Backoff != nil {
+			delay = policy.Backoff(attempt, delay)
+		}
+		if policy.MaxElapsed > 0 && clk.Now().Add(delay).Sub(start) > policy.MaxElapsed {
+			errs.add(ErrMaxElapsed)
+			return giveUp[T](attempt, errs)
+		}
+		if ctx.Err() != nil {
+			errs.add(context.Cause(ctx))
+			return giveUp[T](attempt, errs)
+		}
+		if delay > 0 {
+			select {
+			case <-clk.After(delay):
+			case <-ctx.Done():
+				errs.add(context.Cause(ctx))
+				return giveUp[T](attempt, errs)
+			}
+		}
+	}
+}
+
+// errorList collects the errors of Do, keeping at most max of them when max is positive.
+type errorList struct {
+	max     int
+	errs    []error
+	dropped int
+}
+
+func (l *errorList) add(err error) {
+	if l.max > 0 && len(l.errs) == l.max {
+		l.errs = append(l.errs[:0], l.errs[1:]...)
+		l.dropped++
+	}
+	l.errs = append(l.errs, err)
+}
+
+// giveUp builds the final Err Result of Do.
+func giveUp[T any](attempts int, errs *errorList) result.Result[T] {
+	return result.Err[T](&Error{Attempts: attempts, Errors: errs.errs, Dropped: errs.dropped})
+}
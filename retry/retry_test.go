@@ -0,0 +1,214 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/azat-dev/go-utils/clock"
+	"github.com/azat-dev/go-utils/result"
+)
+
+var (
+	errTemporary = errors.New("temporary")
+	errPermanent = errors.New("permanent")
+)
+
+// instantClock advances a fake clock by every requested delay instead of waiting, and records the delays.
+type instantClock struct {
+	*clock.Fake
+	delays []time.Duration
+}
+
+func newInstantClock() *instantClock {
+	return &instantClock{
+		Fake:   clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+		delays: nil,
+	}
+}
+
+func (c *instantClock) After(d time.Duration) <-chan time.Time {
+	c.delays = append(c.delays, d)
+	ch := c.Fake.After(d)
+	c.Advance(d)
+	return ch
+}
+
+// failing returns an operation that fails with errs in order and then succeeds with value.
+func failing(value int, errs ...error) (func(context.Context) result.Result[int], *int) {
+	calls := 0
+	return func(context.Context) result.Result[int] {
+		calls++
+		if calls <= len(errs) {
+			return result.Err[int](errs[calls-1])
+		}
+		return result.Ok(value)
+	}, &calls
+}
+
+func testPolicy(clk clock.Clock) Policy {
+	policy := DefaultPolicy()
+	policy.Clock = clk
+	return policy
+}
+
+func TestDo(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("succeeds after retries", func(t *testing.T) {
+		clk := newInstantClock()
+		op, calls := failing(42, errTemporary, errTemporary)
+		r := Do(ctx, testPolicy(clk), op)
+		if v := r.Unwrap(); v != 42 {
+			t.Errorf("Expected 42, got %d", v)
+		}
+		if *calls != 3 {
+			t.Errorf("Expected 3 calls, got %d", *calls)
+		}
+		expected := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}
+		if len(clk.delays) != 2 || clk.delays[0] != expected[0] || clk.delays[1] != expected[1] {
+			t.Errorf("Expected delays %v, got %v", expected, clk.delays)
+		}
+	})
+
+	t.Run("wraps every attempt error", func(t *testing.T) {
+		first := errors.New("first")
+		second := errors.New("second")
+		third := errors.New("third")
+		op, calls := failing(0, first, second, third)
+		r := Do(ctx, testPolicy(newInstantClock()), op)
+		if *calls != 3 {
+			t.Errorf("Expected 3 calls, got %d", *calls)
+		}
+		retryErr := result.ErrAs[*Error](r).Unwrap()
+		if retryErr.Attempts != 3 || len(retryErr.Errors) != 3 {
+			t.Errorf("Unexpected error %v", retryErr)
+		}
+		if !r.ErrIs(first) || !r.ErrIs(second) || !r.ErrIs(third) {
+			t.Error("Expected errors.Is to match every attempt error")
+		}
+		expectedMsg := "retry: giving up after 3 attempt(s): third"
+		if retryErr.Error() != expectedMsg {
+			t.Errorf("Expected message '%s', got '%s'", expectedMsg, retryErr.Error())
+		}
+	})
+
+	t.Run("stops on non-retryable error", func(t *testing.T) {
+		policy := testPolicy(newInstantClock())
+		policy.Retryable = func(err error) bool { return !errors.Is(err, errPermanent) }
+		op, calls := failing(0, errTemporary, errPermanent, errTemporary)
+		r := Do(ctx, policy, op)
+		if *calls != 2 {
+			t.Errorf("Expected 2 calls, got %d", *calls)
+		}
+		if !r.ErrIs(errPermanent) {
+			t.Errorf("Expected permanent error, got %v", r)
+		}
+	})
+
+	t.Run("unlimited attempts", func(t *testing.T) {
+		policy := testPolicy(newInstantClock())
+		policy.MaxAttempts = Unlimited
+		op, calls := failing(1, errTemporary, errTemporary, errTemporary, errTemporary, errTemporary)
+		if v := Do(ctx, policy, op).Unwrap(); v != 1 || *calls != 6 {
+			t.Errorf("Expected success after 6 calls, got %d after %d", v, *calls)
+		}
+	})
+
+	t.Run("zero policy makes a single attempt", func(t *testing.T) {
+		op, calls := failing(1, errTemporary)
+		r := Do(ctx, Policy{}, op)
+		if *calls != 1 || !r.ErrIs(errTemporary) {
+			t.Errorf("Expected errTemporary after 1 call, got %v after %d", r, *calls)
+		}
+	})
+
+	t.Run("keeps every attempt error", func(t *testing.T) {
+		policy := testPolicy(newInstantClock())
+		policy.MaxAttempts = 250
+		policy.Backoff = nil
+		errs := make([]error, 250)
+		for i := range errs {
+			errs[i] = fmt.Errorf("attempt %d", i+1)
+		}
+		op, _ := failing(0, errs...)
+		retryErr := result.ErrAs[*Error](Do(ctx, policy, op)).Unwrap()
+		if retryErr.Attempts != 250 || len(retryErr.Errors) != 250 || retryErr.Dropped != 0 {
+			t.Errorf("Expected 250 attempts with 250 errors, got %d with %d, %d dropped",
+				retryErr.Attempts, len(retryErr.Errors), retryErr.Dropped)
+		}
+	})
+
+	t.Run("MaxErrors keeps the last errors", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		policy := testPolicy(newInstantClock())
+		policy.MaxAttempts = Unlimited
+		policy.Backoff = nil
+		policy.MaxErrors = 3
+		calls := 0
+		op := func(context.Context) result.Result[int] {
+			calls++
+			if calls == 5 {
+				cancel()
+			}
+			return result.Err[int](fmt.Errorf("attempt %d", calls))
+		}
+		retryErr := result.ErrAs[*Error](Do(ctx, policy, op)).Unwrap()
+		got := make([]string, len(retryErr.Errors))
+		for i, err := range retryErr.Errors {
+			got[i] = err.Error()
+		}
+		want := []string{"attempt 4", "attempt 5", "context canceled"}
+		if !slices.Equal(got, want) || retryErr.Attempts != 5 || retryErr.Dropped != 3 {
+			t.Errorf("Expected %v after 5 attempts with 3 dropped, got %v after %d with %d dropped",
+				want, got, retryErr.Attempts, retryErr.Dropped)
+		}
+	})
+
+	t.Run("max elapsed time", func(t *testing.T) {
+		clk := newInstantClock()
+		policy := testPolicy(clk)
+		policy.MaxAttempts = Unlimited
+		policy.Backoff = Constant(time.Second)
+		policy.MaxElapsed = 2500 * time.Millisecond
+		op, calls := failing(0, errTemporary, errTemporary, errTemporary, errTemporary)
+		r := Do(ctx, policy, op)
+		if *calls != 3 {
+			t.Errorf("Expected 3 calls, got %d", *calls)
+		}
+		if !r.ErrIs(ErrMaxElapsed) || !r.ErrIs(errTemporary) {
+			t.Errorf("Expected ErrMaxElapsed with attempt errors, got %v", r)
+		}
+	})
+
+	t.Run("context cancelled while waiting", func(t *testing.T) {
+		fake := clock.NewFake(time.Now())
+		policy := testPolicy(fake)
+		cancelled, cancel := context.WithCancel(ctx)
+		op, calls := failing(0, errTemporary, errTemporary)
+		done := make(chan result.Result[int])
+		go func() { done <- Do(cancelled, policy, op) }()
+		for fake.Waiters() == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		cancel()
+		r := <-done
+		if *calls != 1 {
+			t.Errorf("Expected 1 call, got %d", *calls)
+		}
+		if !r.ErrIs(context.Canceled) || !r.ErrIs(errTemporary) {
+			t.Errorf("Expected context.Canceled with attempt error, got %v", r)
+		}
+	})
+
+	t.Run("Ok on first attempt", func(t *testing.T) {
+		clk := newInstantClock()
+		op, calls := failing(7)
+		if v := Do(ctx, testPolicy(clk), op).Unwrap(); v != 7 || *calls != 1 || len(clk.delays) != 0 {
+			t.Errorf("Expected immediate success, got %d after %d calls", v, *calls)
+		}
+	})
+}
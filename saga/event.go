@@ -0,0 +1,112 @@
+package saga
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// EventType says what happened in an Event.
+type EventType int
+
+const (
+	// StepStarted is recorded before every attempt of a step's Do.
+	StepStarted EventType = iota
+	// StepSucceeded is recorded when a step's Do returned Ok.
+	StepSucceeded
+	// StepFailed is recorded for every attempt of a step's Do that returned Err, including retried ones.
+	StepFailed
+	// CompensationStarted is recorded before every attempt of a step's Compensate.
+	CompensationStarted
+	// CompensationSucceeded is recorded when a step's Compensate returned nil.
+	CompensationSucceeded
+	// CompensationFailed is recorded for every attempt of a step's Compensate that returned an error.
+	CompensationFailed
+	// SagaCompleted is recorded when every step succeeded.
+	SagaCompleted
+	// SagaAborted is recorded when a step failed, after compensation finished.
+	SagaAborted
+)
+
+// String returns the lowercase name of the event type, such as "step failed".
+func (t EventType) String() string {
+	switch t {
+	case StepStarted:
+		return "step started"
+	case StepSucceeded:
+		return "step succeeded"
+	case StepFailed:
+		return "step failed"
+	case CompensationStarted:
+		return "compensation started"
+	case CompensationSucceeded:
+		return "compensation succeeded"
+	case CompensationFailed:
+		return "compensation failed"
+	case SagaCompleted:
+		return "saga completed"
+	case SagaAborted:
+		return "saga aborted"
+	default:
+		return "unknown"
+	}
+}
+
+// Event is one entry of the history of a saga execution.
+type Event struct {
+	Type EventType
+	// Step is the name of the step, empty for SagaCompleted and SagaAborted.
+	Step string
+	// Attempt counts the attempts of the step's Do or Compensate, from 1. Zero for saga events.
+	Attempt int
+	// Err is the error of a failed attempt, or the saga error for SagaAborted.
+	Err error
+	// At is the time of the event, taken from Config.Clock.
+	At time.Time
+}
+
+// String describes the event without its time, for example `charge: step failed (attempt 2): card declined`.
+func (e Event) String() string {
+	s := e.Type.String()
+	if e.Step != "" {
+		s = e.Step + ": " + s
+	}
+	if e.Attempt > 0 {
+		s += fmt.Sprintf(" (attempt %d)", e.Attempt)
+	}
+	if e.Err != nil {
+		s += ": " + e.Err.Error()
+	}
+	return s
+}
+
+// Log collects events, typically as Config.OnEvent, so a saga's history can be inspected afterwards.
+// It is safe for concurrent use.
+type Log struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+// Record appends an event to the log.
+func (l *Log) Record(e Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, e)
+}
+
+// Events returns the recorded events in order.
+func (l *Log) Events() []Event {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]Event(nil), l.events...)
+}
+
+// Strings returns the String form of every recorded event, convenient to compare in tests.
+func (l *Log) Strings() []string {
+	events := l.Events()
+	s := make([]string, len(events))
+	for i, e := range events {
+		s[i] = e.String()
+	}
+	return s
+}
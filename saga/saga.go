@@ -0,0 +1,221 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	go_utils "github.com/azat-dev/go-utils"
+	"github.com/azat-dev/go-utils/clock"
+	"github.com/azat-dev/go-utils/optional"
+	"github.com/azat-dev/go-utils/result"
+	"github.com/azat-dev/go-utils/retry"
+)
+
+// ErrNilState is returned in an Err Result by Execute when the initial state is nil, which a Result can't hold,
+// and is the failure of a step whose Do returns Ok with a nil state, for example through a zero Result.
+var ErrNilState = errors.New("saga: nil state")
+
+// Step is one action of a saga together with the action undoing it.
+type Step[S any] struct {
+	// Name identifies the step in events and errors. Empty means "step N", counting from 1.
+	Name string
+	// Do performs the step and returns the state passed to the next one.
+	Do func(ctx context.Context, state S) result.Result[S]
+	// Compensate undoes a completed step. It receives the state Do returned. Nil means there is nothing to undo.
+	Compensate func(ctx context.Context, state S) error
+	// Retry, if set, runs Do again when it fails, following the policy.
+	Retry optional.Optional[retry.Policy]
+	// CompensateRetry, if set, runs Compensate again when it fails, following the policy.
+	CompensateRetry optional.Optional[retry.Policy]
+}
+
+// Config controls how a Saga reports its progress.
+type Config struct {
+	// OnEvent is called synchronously for every event. May be nil; see Log for recording them.
+	OnEvent func(Event)
+	// Clock timestamps the events. Nil means clock.Real().
+	Clock clock.Clock
+}
+
+// DefaultConfig returns a Config without an event hook, using the real clock.
+func DefaultConfig() Config {
+	return Config{OnEvent: nil, Clock: nil}
+}
+
+// Saga runs steps in order and undoes the completed ones in reverse order when a step fails.
+// A Saga holds no execution state, so it can be executed any number of times, concurrently.
+type Saga[S any] struct {
+	config Config
+	steps  []Step[S]
+}
+
+// New creates a Saga running the given steps in order.
+func New[S any](config Config, steps ...Step[S]) *Saga[S] {
+	if config.Clock == nil {
+		config.Clock = clock.Real()
+	}
+	named := make([]Step[S], len(steps))
+	for i, step := range steps {
+		if step.Name == "" {
+			step.Name = "step " + strconv.Itoa(i+1)
+		}
+		named[i] = step
+	}
+	return &Saga[S]{config: config, steps: named}
+}
+
+// Error is stored in the Err Result returned by Execute when a step failed.
+// It unwraps to the cause and to every compensation failure, so errors.Is and errors.As see all of them.
+type Error struct {
+	// Step is the name of the step that failed.
+	Step string
+	// Cause is the error of the failed step.
+	Cause error
+	// Compensations holds a *CompensationError for every completed step that could not be undone.
+	Compensations []error
+}
+
+// Error describes the failed step and any compensation failures.
+func (e *Error) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "saga: step %q failed: %v", e.Step, e.Cause)
+	for _, err := range e.Compensations {
+		sb.WriteString("; ")
+		sb.WriteString(err.Error())
+	}
+	return sb.String()
+}
+
+// Unwrap returns the cause followed by the compensation errors.
+func (e *Error) Unwrap() []error {
+	return append([]error{e.Cause}, e.Compensations...)
+}
+
+// CompensationError is the failure to undo one completed step.
+type CompensationError struct {
+	Step string
+	Err  error
+}
+
+// Error names the step that could not be compensated.
+func (e *CompensationError) Error() string {
+	return fmt.Sprintf("compensation of step %q failed: %v", e.Step, e.Err)
+}
+
+// Unwrap returns the error returned by Compensate.
+func (e *CompensationError) Unwrap() error {
+	return e.Err
+}
+
+// completed is a step whose Do succeeded, with the state it returned.
+type completed[S any] struct {
+	step  Step[S]
+	state S
+}
+
+// Execute runs the steps in order, passing each the state returned by the previous one, starting from state.
+// It returns the state of the last step, or, when a step fails, compensates the completed steps
+// in reverse order and returns an Err holding an *Error. A panic in Do or Compensate is recovered
+// and handled as a failure holding a *result.PanicError.
+//
+// Compensation runs even if ctx is canceled, with a context that keeps ctx's values but not its cancellation,
+// since leaving completed steps in place is usually worse than finishing the undo.
+//
+// A nil initial state is rejected with ErrNilState before any step runs or any event is emitted.
+// A step whose Do returns a nil state without an error fails with ErrNilState like any other failing step.
+func (s *Saga[S]) Execute(ctx context.Context, state S) result.Result[S] {
+	if go_utils.IsNil(state) {
+		return result.Err[S](ErrNilState)
+	}
+	done := make([]completed[S], 0, len(s.steps))
+	for _, step := range s.steps {
+		next, err := s.do(ctx, step, state).Get()
+		if err != nil {
+			sagaErr := &Error{Step: step.Name, Cause: err, Compensations: s.compensate(context.WithoutCancel(ctx), done)}
+			s.emit(Event{Type: SagaAborted, Err: sagaErr})
+			return result.Err[S](sagaErr)
+		}
+		state = next
+		done = append(done, completed[S]{step: step, state: state})
+	}
+	s.emit(Event{Type: SagaCompleted})
+	return result.Ok(state)
+}
+
+// do runs the Do of a step, retrying it if the step has a policy.
+func (s *Saga[S]) do(ctx context.Context, step Step[S], state S) result.Result[S] {
+	attempt := 0
+	op := func(ctx context.Context) result.Result[S] {
+		attempt++
+		s.emit(Event{Type: StepStarted, Step: step.Name, Attempt: attempt})
+		r := callDo(ctx, step.Do, state)
+		if _, err := r.Get(); err != nil {
+			s.emit(Event{Type: StepFailed, Step: step.Name, Attempt: attempt, Err: err})
+		} else {
+			s.emit(Event{Type: StepSucceeded, Step: step.Name, Attempt: attempt})
+		}
+		return r
+	}
+	if policy, ok := step.Retry.Get(); ok {
+		return retry.Do(ctx, policy, op)
+	}
+	return op(ctx)
+}
+
+// compensate undoes the completed steps in reverse order and returns the failures.
+func (s *Saga[S]) compensate(ctx context.Context, done []completed[S]) []error {
+	var errs []error
+	for i := len(done) - 1; i >= 0; i-- {
+		step := done[i].step
+		if step.Compensate == nil {
+			continue
+		}
+		attempt := 0
+		op := func(ctx context.Context) result.Result[struct{}] {
+			attempt++
+			s.emit(Event{Type: CompensationStarted, Step: step.Name, Attempt: attempt})
+			r := callCompensate(ctx, step.Compensate, done[i].state)
+			if _, err := r.Get(); err != nil {
+				s.emit(Event{Type: CompensationFailed, Step: step.Name, Attempt: attempt, Err: err})
+			} else {
+				s.emit(Event{Type: CompensationSucceeded, Step: step.Name, Attempt: attempt})
+			}
+			return r
+		}
+		var r result.Result[struct{}]
+		if policy, ok := step.CompensateRetry.Get(); ok {
+			r = retry.Do(ctx, policy, op)
+		} else {
+			r = op(ctx)
+		}
+		if _, err := r.Get(); err != nil {
+			errs = append(errs, &CompensationError{Step: step.Name, Err: err})
+		}
+	}
+	return errs
+}
+
+func (s *Saga[S]) emit(e Event) {
+	if s.config.OnEvent == nil {
+		return
+	}
+	e.At = s.config.Clock.Now()
+	s.config.OnEvent(e)
+}
+
+func callDo[S any](ctx context.Context, do func(context.Context, S) result.Result[S], state S) (r result.Result[S]) {
+	defer result.Catch(&r)
+	r = do(ctx, state)
+	if next, err := r.Get(); err == nil && go_utils.IsNil(next) {
+		return result.Err[S](ErrNilState)
+	}
+	return r
+}
+
+func callCompensate[S any](ctx context.Context, compensate func(context.Context, S) error, state S) (r result.Result[struct{}]) {
+	defer result.Catch(&r)
+	return result.From(struct{}{}, compensate(ctx, state))
+}
@@ -0,0 +1,326 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/azat-dev/go-utils/clock"
+	"github.com/azat-dev/go-utils/optional"
+	"github.com/azat-dev/go-utils/result"
+	"github.com/azat-dev/go-utils/retry"
+)
+
+type order struct {
+	Reserved bool
+	Charged  bool
+	Shipped  bool
+}
+
+var (
+	errOutOfStock   = errors.New("out of stock")
+	errDeclined     = errors.New("card declined")
+	errRefundFailed = errors.New("refund failed")
+)
+
+func reserve() Step[order] {
+	return Step[order]{
+		Name: "reserve",
+		Do: func(_ context.Context, o order) result.Result[order] {
+			o.Reserved = true
+			return result.Ok(o)
+		},
+		Compensate: func(context.Context, order) error { return nil },
+	}
+}
+
+func charge(err error) Step[order] {
+	return Step[order]{
+		Name: "charge",
+		Do: func(_ context.Context, o order) result.Result[order] {
+			if err != nil {
+				return result.Err[order](err)
+			}
+			o.Charged = true
+			return result.Ok(o)
+		},
+		Compensate: func(context.Context, order) error { return nil },
+	}
+}
+
+func ship(err error) Step[order] {
+	return Step[order]{
+		Name: "ship",
+		Do: func(_ context.Context, o order) result.Result[order] {
+			if err != nil {
+				return result.Err[order](err)
+			}
+			o.Shipped = true
+			return result.Ok(o)
+		},
+		Compensate: nil,
+	}
+}
+
+func newSaga(log *Log, steps ...Step[order]) *Saga[order] {
+	return New(Config{OnEvent: log.Record, Clock: clock.NewFake(time.Unix(0, 0))}, steps...)
+}
+
+func checkEvents(t *testing.T, log *Log, want ...string) {
+	t.Helper()
+	if got := log.Strings(); !slices.Equal(got, want) {
+		t.Errorf("Expected events:\n%s\ngot:\n%s", strings.Join(want, "\n"), strings.Join(got, "\n"))
+	}
+}
+
+func TestExecuteSuccess(t *testing.T) {
+	log := &Log{}
+	s := newSaga(log, reserve(), charge(nil), ship(nil))
+
+	o, err := s.Execute(context.Background(), order{}).Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !o.Reserved || !o.Charged || !o.Shipped {
+		t.Errorf("Expected every step applied, got %+v", o)
+	}
+	checkEvents(t, log,
+		"reserve: step started (attempt 1)", "reserve: step succeeded (attempt 1)",
+		"charge: step started (attempt 1)", "charge: step succeeded (attempt 1)",
+		"ship: step started (attempt 1)", "ship: step succeeded (attempt 1)",
+		"saga completed",
+	)
+	if at := log.Events()[0].At; !at.Equal(time.Unix(0, 0)) {
+		t.Errorf("Expected events timestamped by the clock, got %v", at)
+	}
+}
+
+func TestExecuteCompensatesInReverse(t *testing.T) {
+	var compensated []string
+	var compensatedStates []order
+	track := func(step Step[order]) Step[order] {
+		step.Compensate = func(_ context.Context, o order) error {
+			compensated = append(compensated, step.Name)
+			compensatedStates = append(compensatedStates, o)
+			return nil
+		}
+		return step
+	}
+	log := &Log{}
+	s := newSaga(log, track(reserve()), track(charge(nil)), ship(errOutOfStock))
+
+	_, err := s.Execute(context.Background(), order{}).Get()
+	var sagaErr *Error
+	if !errors.As(err, &sagaErr) {
+		t.Fatalf("Expected *Error, got %v", err)
+	}
+	if sagaErr.Step != "ship" || !errors.Is(err, errOutOfStock) || len(sagaErr.Compensations) != 0 {
+		t.Errorf("Unexpected error %+v", sagaErr)
+	}
+	if !slices.Equal(compensated, []string{"charge", "reserve"}) {
+		t.Errorf("Expected compensation in reverse order, got %v", compensated)
+	}
+	if compensatedStates[0] != (order{Reserved: true, Charged: true}) || compensatedStates[1] != (order{Reserved: true}) {
+		t.Errorf("Expected each compensation to get its step's state, got %+v", compensatedStates)
+	}
+	checkEvents(t, log,
+		"reserve: step started (attempt 1)", "reserve: step succeeded (attempt 1)",
+		"charge: step started (attempt 1)", "charge: step succeeded (attempt 1)",
+		"ship: step started (attempt 1)", "ship: step failed (attempt 1): out of stock",
+		"charge: compensation started (attempt 1)", "charge: compensation succeeded (attempt 1)",
+		"reserve: compensation started (attempt 1)", "reserve: compensation succeeded (attempt 1)",
+		`saga aborted: saga: step "ship" failed: out of stock`,
+	)
+}
+
+func TestExecuteCompensationFailures(t *testing.T) {
+	failing := charge(nil)
+	failing.Compensate = func(context.Context, order) error { return errRefundFailed }
+	reserveStep := reserve()
+	reserveCalled := false
+	reserveStep.Compensate = func(context.Context, order) error {
+		reserveCalled = true
+		return nil
+	}
+	s := New(DefaultConfig(), reserveStep, failing, ship(errOutOfStock))
+
+	_, err := s.Execute(context.Background(), order{}).Get()
+	if !errors.Is(err, errOutOfStock) || !errors.Is(err, errRefundFailed) {
+		t.Errorf("Expected cause and compensation failure joined, got %v", err)
+	}
+	var compErr *CompensationError
+	if !errors.As(err, &compErr) || compErr.Step != "charge" {
+		t.Errorf("Expected a *CompensationError for charge, got %v", err)
+	}
+	want := `saga: step "ship" failed: out of stock; compensation of step "charge" failed: refund failed`
+	if err.Error() != want {
+		t.Errorf("Expected message %q, got %q", want, err.Error())
+	}
+	if !reserveCalled {
+		t.Error("Expected compensation to continue after a failure")
+	}
+}
+
+func TestExecuteRetry(t *testing.T) {
+	attempts := 0
+	flaky := charge(nil)
+	flaky.Do = func(_ context.Context, o order) result.Result[order] {
+		attempts++
+		if attempts < 3 {
+			return result.Err[order](errDeclined)
+		}
+		o.Charged = true
+		return result.Ok(o)
+	}
+	flaky.Retry = optional.Some(retry.Policy{MaxAttempts: 3})
+
+	log := &Log{}
+	o, err := newSaga(log, flaky).Execute(context.Background(), order{}).Get()
+	if err != nil || !o.Charged {
+		t.Fatalf("Expected Ok after retries, got %+v, %v", o, err)
+	}
+	checkEvents(t, log,
+		"charge: step started (attempt 1)", "charge: step failed (attempt 1): card declined",
+		"charge: step started (attempt 2)", "charge: step failed (attempt 2): card declined",
+		"charge: step started (attempt 3)", "charge: step succeeded (attempt 3)",
+		"saga completed",
+	)
+}
+
+func TestExecuteCompensateRetry(t *testing.T) {
+	attempts := 0
+	step := reserve()
+	step.Compensate = func(context.Context, order) error {
+		attempts++
+		if attempts < 2 {
+			return errRefundFailed
+		}
+		return nil
+	}
+	step.CompensateRetry = optional.Some(retry.Policy{MaxAttempts: 2})
+
+	log := &Log{}
+	_, err := newSaga(log, step, ship(errOutOfStock)).Execute(context.Background(), order{}).Get()
+	var sagaErr *Error
+	if !errors.As(err, &sagaErr) || len(sagaErr.Compensations) != 0 {
+		t.Errorf("Expected compensation to succeed on retry, got %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("Expected 2 compensation attempts, got %d", attempts)
+	}
+}
+
+func TestExecutePanic(t *testing.T) {
+	compensated := false
+	step := reserve()
+	step.Compensate = func(context.Context, order) error {
+		compensated = true
+		return nil
+	}
+	boom := Step[order]{Do: func(context.Context, order) result.Result[order] { panic("boom") }}
+
+	_, err := New(DefaultConfig(), step, boom).Execute(context.Background(), order{}).Get()
+	var panicErr *result.PanicError
+	if !errors.As(err, &panicErr) || panicErr.Value != "boom" {
+		t.Errorf("Expected a recovered panic, got %v", err)
+	}
+	var sagaErr *Error
+	if !errors.As(err, &sagaErr) || sagaErr.Step != "step 2" {
+		t.Errorf("Expected unnamed step to be called 'step 2', got %v", err)
+	}
+	if !compensated {
+		t.Error("Expected completed steps to be compensated after a panic")
+	}
+}
+
+func TestExecuteCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var compensateCtxErr error
+	step := reserve()
+	step.Compensate = func(ctx context.Context, _ order) error {
+		compensateCtxErr = ctx.Err()
+		return nil
+	}
+	cancelling := Step[order]{
+		Name: "cancel",
+		Do: func(ctx context.Context, o order) result.Result[order] {
+			cancel()
+			return result.Err[order](ctx.Err())
+		},
+	}
+
+	_, err := New(DefaultConfig(), step, cancelling).Execute(ctx, order{}).Get()
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled as the cause, got %v", err)
+	}
+	if compensateCtxErr != nil {
+		t.Errorf("Expected compensation context not to be canceled, got %v", compensateCtxErr)
+	}
+}
+
+func TestExecuteNilState(t *testing.T) {
+	var log Log
+	config := DefaultConfig()
+	config.OnEvent = log.Record
+	calls := 0
+	step := Step[map[string]int]{
+		Name: "count",
+		Do: func(_ context.Context, m map[string]int) result.Result[map[string]int] {
+			calls++
+			return result.Ok(m)
+		},
+	}
+
+	for _, s := range []*Saga[map[string]int]{New[map[string]int](config), New(config, step)} {
+		_, err := s.Execute(context.Background(), nil).Get()
+		if !errors.Is(err, ErrNilState) {
+			t.Errorf("Expected ErrNilState, got %v", err)
+		}
+	}
+	if calls != 0 || len(log.Events()) != 0 {
+		t.Errorf("Expected no step to run and no event, got %d calls and %v", calls, log.Strings())
+	}
+}
+
+func TestExecuteStepReturningNilState(t *testing.T) {
+	var compensated []string
+	step := func(name string, do func(map[string]int) result.Result[map[string]int]) Step[map[string]int] {
+		return Step[map[string]int]{
+			Name: name,
+			Do:   func(_ context.Context, m map[string]int) result.Result[map[string]int] { return do(m) },
+			Compensate: func(context.Context, map[string]int) error {
+				compensated = append(compensated, name)
+				return nil
+			},
+		}
+	}
+	keep := step("keep", func(m map[string]int) result.Result[map[string]int] { return result.Ok(m) })
+
+	tests := []struct {
+		lost Step[map[string]int]
+		want error
+	}{
+		{step("zero", func(map[string]int) result.Result[map[string]int] { return result.Result[map[string]int]{} }), ErrNilState},
+		{step("from", func(map[string]int) result.Result[map[string]int] { return result.From[map[string]int](nil, nil) }), result.ErrNilValue},
+	}
+	for _, tt := range tests {
+		lost := tt.lost
+		compensated = nil
+		var log Log
+		config := DefaultConfig()
+		config.OnEvent = log.Record
+		s := New(config, keep, lost)
+
+		_, err := s.Execute(context.Background(), map[string]int{}).Get()
+		var sagaErr *Error
+		if !errors.As(err, &sagaErr) || sagaErr.Step != lost.Name || !errors.Is(err, tt.want) {
+			t.Errorf("Expected %s to fail with %v, got %v", lost.Name, tt.want, err)
+		}
+		if !slices.Equal(compensated, []string{"keep"}) {
+			t.Errorf("Expected keep to be compensated, got %v", compensated)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package stream
+
+import (
+	"context"
+	"sync"
+
+	"github.com/azat-dev/go-utils/result"
+)
+
+// ParallelMap returns a stream of f applied to every Ok value using at most workers goroutines.
+// The output keeps the order of the input, and at most workers values are in flight at a time,
+// so a slow consumer stops the workers from reading ahead. Err elements pass through unchanged.
+// A panic in f is recovered into an Err holding a *result.PanicError for that element only.
+// When the consumer stops early, the context passed to f is canceled. If workers is less than 1, 1 is used.
+func ParallelMap[T, U any](s Stream[T], workers int, f func(context.Context, T) result.Result[U]) Stream[U] {
+	workers = max(workers, 1)
+	return derive(s, func(ctx context.Context, yield func(result.Result[U]) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		// pending holds one channel per element in input order; its capacity together with
+		// the semaphore bounds how far the producer can get ahead of the consumer.
+		pending := make(chan chan result.Result[U], workers)
+		sem := make(chan struct{}, workers)
+		var wg sync.WaitGroup
+		var upstreamPanic any
+
+		go func() {
+			defer close(pending)
+			defer func() {
+				upstreamPanic = recover()
+			}()
+			s.run(ctx, func(r result.Result[T]) bool {
+				out := make(chan result.Result[U], 1)
+				if v, err := r.Get(); err != nil {
+					out <- result.Err[U](err)
+				} else {
+					select {
+					case sem <- struct{}{}:
+					case <-ctx.Done():
+						return false
+					}
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						out <- callRecovering(ctx, v, f)
+						<-sem
+					}()
+				}
+				select {
+				case pending <- out:
+					return true
+				case <-ctx.Done():
+					return false
+				}
+			})
+		}()
+
+		for out := range pending {
+			if !yield(<-out) {
+				break
+			}
+		}
+		cancel()
+		for range pending {
+		}
+		wg.Wait()
+		if upstreamPanic != nil {
+			panic(upstreamPanic)
+		}
+	})
+}
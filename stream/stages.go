@@ -0,0 +1,136 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/azat-dev/go-utils/result"
+)
+
+// Map returns a stream of f applied to every Ok value. Err elements pass through unchanged.
+func Map[T, U any](s Stream[T], f func(T) U) Stream[U] {
+	return TryMap(s, func(_ context.Context, v T) result.Result[U] {
+		return ok(f(v))
+	})
+}
+
+// TryMap returns a stream of f applied to every Ok value, where f may fail with an Err.
+// Err elements pass through unchanged. A panic in f is recovered into an Err holding a *result.PanicError.
+func TryMap[T, U any](s Stream[T], f func(context.Context, T) result.Result[U]) Stream[U] {
+	return derive(s, func(ctx context.Context, yield func(result.Result[U]) bool) {
+		s.run(ctx, func(r result.Result[T]) bool {
+			v, err := r.Get()
+			if err != nil {
+				return yield(result.Err[U](err))
+			}
+			return yield(callRecovering(ctx, v, f))
+		})
+	})
+}
+
+// Filter returns a stream of the Ok values for which keep returns true. Err elements are always kept.
+func Filter[T any](s Stream[T], keep func(T) bool) Stream[T] {
+	return derive(s, func(ctx context.Context, yield func(result.Result[T]) bool) {
+		s.run(ctx, func(r result.Result[T]) bool {
+			if v, err := r.Get(); err == nil && !keep(v) {
+				return true
+			}
+			return yield(r)
+		})
+	})
+}
+
+// FlatMap returns a stream of the elements of the streams f returns for every Ok value, in order.
+// Err elements pass through unchanged.
+func FlatMap[T, U any](s Stream[T], f func(T) Stream[U]) Stream[U] {
+	return derive(s, func(ctx context.Context, yield func(result.Result[U]) bool) {
+		s.run(ctx, func(r result.Result[T]) bool {
+			v, err := r.Get()
+			if err != nil {
+				return yield(result.Err[U](err))
+			}
+			more := true
+			f(v).run(ctx, func(r result.Result[U]) bool {
+				more = yield(r)
+				return more
+			})
+			return more
+		})
+	})
+}
+
+// Batch returns a stream of consecutive Ok values grouped into slices of size, the last one possibly shorter.
+// An Err element first flushes the batch collected so far and then passes through, so the order is kept.
+// Panics if size is less than 1.
+func Batch[T any](s Stream[T], size int) Stream[[]T] {
+	if size < 1 {
+		panic(fmt.Sprintf("stream: batch size must be positive, got %d", size))
+	}
+	return derive(s, func(ctx context.Context, yield func(result.Result[[]T]) bool) {
+		batch := make([]T, 0, size)
+		flush := func() bool {
+			if len(batch) == 0 {
+				return true
+			}
+			full := batch
+			batch = make([]T, 0, size)
+			return yield(result.Ok(full))
+		}
+		more := true
+		s.run(ctx, func(r result.Result[T]) bool {
+			v, err := r.Get()
+			if err != nil {
+				more = flush() && yield(result.Err[[]T](err))
+				return more
+			}
+			if batch = append(batch, v); len(batch) == size {
+				more = flush()
+			}
+			return more
+		})
+		if more && ctx.Err() == nil {
+			flush()
+		}
+	})
+}
+
+// Window returns a stream of sliding windows of size consecutive Ok values, starting step values apart.
+// With step equal to size the windows don't overlap; with step greater than size values are skipped
+// between them. Only full windows are emitted. Err elements pass through without resetting the window.
+// Panics if size or step is less than 1.
+func Window[T any](s Stream[T], size, step int) Stream[[]T] {
+	if size < 1 || step < 1 {
+		panic(fmt.Sprintf("stream: window size and step must be positive, got %d and %d", size, step))
+	}
+	return derive(s, func(ctx context.Context, yield func(result.Result[[]T]) bool) {
+		window := make([]T, 0, size)
+		skip := 0
+		s.run(ctx, func(r result.Result[T]) bool {
+			v, err := r.Get()
+			if err != nil {
+				return yield(result.Err[[]T](err))
+			}
+			if skip > 0 {
+				skip--
+				return true
+			}
+			if window = append(window, v); len(window) < size {
+				return true
+			}
+			full := window
+			window = make([]T, 0, size)
+			if step < size {
+				window = append(window, full[step:]...)
+			} else {
+				skip = step - size
+			}
+			return yield(result.Ok(full))
+		})
+	})
+}
+
+// callRecovering calls f and converts a panic into an Err.
+func callRecovering[T, U any](ctx context.Context, v T, f func(context.Context, T) result.Result[U]) (r result.Result[U]) {
+	defer result.Catch(&r)
+	return f(ctx, v)
+}
@@ -0,0 +1,158 @@
+package stream
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"iter"
+
+	go_utils "github.com/azat-dev/go-utils"
+	"github.com/azat-dev/go-utils/result"
+)
+
+// ErrNilValue is the error of the Err element a source or stage produces in place of a nil value,
+// since a Result can't hold one.
+var ErrNilValue = errors.New("stream: nil value")
+
+// ErrorMode decides what the terminal operations do with Err elements.
+type ErrorMode int
+
+const (
+	// FailFast stops the pipeline at the first Err element and returns it.
+	FailFast ErrorMode = iota
+	// SkipAndLog passes every Err element to Config.OnError, drops it and keeps going.
+	SkipAndLog
+	// CollectErrors keeps going past Err elements and returns all of them joined once the stream ends.
+	CollectErrors
+)
+
+// String returns the lowercase name of the mode.
+func (m ErrorMode) String() string {
+	switch m {
+	case FailFast:
+		return "fail-fast"
+	case SkipAndLog:
+		return "skip-and-log"
+	case CollectErrors:
+		return "collect"
+	default:
+		return "unknown"
+	}
+}
+
+// Config controls how the terminal operations handle Err elements.
+type Config struct {
+	// Errors is the error policy. The zero value is FailFast.
+	Errors ErrorMode
+	// OnError is called for every Err element under SkipAndLog and CollectErrors. May be nil.
+	OnError func(error)
+}
+
+// DefaultConfig returns a Config that fails fast.
+func DefaultConfig() Config {
+	return Config{
+		Errors:  FailFast,
+		OnError: nil,
+	}
+}
+
+// Stream is a lazy sequence of elements, each either an Ok value or an Err.
+// Nothing runs until a terminal operation such as Collect, Reduce or ForEach pulls the elements,
+// and every element is produced only when the consumer is ready for it, so a slow consumer
+// slows the whole pipeline down instead of letting elements pile up.
+// A Stream can be consumed more than once if its source can; a channel source can't.
+type Stream[T any] struct {
+	config Config
+	run    func(ctx context.Context, yield func(result.Result[T]) bool)
+}
+
+// WithConfig returns a copy of the stream that uses config in its terminal operations.
+// Stages keep the config of the stream they are applied to.
+func (s Stream[T]) WithConfig(config Config) Stream[T] {
+	s.config = config
+	return s
+}
+
+// All returns an iterator over the elements, Err elements included.
+// It stops early when ctx is done; breaking out of the loop stops the pipeline.
+func (s Stream[T]) All(ctx context.Context) iter.Seq[result.Result[T]] {
+	return func(yield func(result.Result[T]) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		s.run(ctx, yield)
+	}
+}
+
+// FromSlice returns a stream of the items.
+func FromSlice[T any](items []T) Stream[T] {
+	return FromSeq(func(yield func(T) bool) {
+		for _, item := range items {
+			if !yield(item) {
+				return
+			}
+		}
+	})
+}
+
+// FromSeq returns a stream of the values produced by seq.
+func FromSeq[T any](seq iter.Seq[T]) Stream[T] {
+	return newStream(func(ctx context.Context, yield func(result.Result[T]) bool) {
+		for v := range seq {
+			if ctx.Err() != nil || !yield(ok(v)) {
+				return
+			}
+		}
+	})
+}
+
+// FromChannel returns a stream of the values received from ch until it is closed.
+// The stream can only be consumed once, since the values are taken off the channel.
+func FromChannel[T any](ch <-chan T) Stream[T] {
+	return newStream(func(ctx context.Context, yield func(result.Result[T]) bool) {
+		for {
+			select {
+			case v, open := <-ch:
+				if !open || !yield(ok(v)) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+}
+
+// Lines returns a stream of the lines read from r, without their line endings.
+// A read error, including a line longer than bufio.MaxScanTokenSize, ends the stream with an Err.
+// Cancellation is checked between lines, so a Read that blocks isn't interrupted.
+func Lines(r io.Reader) Stream[string] {
+	return newStream(func(ctx context.Context, yield func(result.Result[string]) bool) {
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			if ctx.Err() != nil || !yield(result.Ok(scanner.Text())) {
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			yield(result.Err[string](err))
+		}
+	})
+}
+
+func newStream[T any](run func(ctx context.Context, yield func(result.Result[T]) bool)) Stream[T] {
+	return Stream[T]{config: DefaultConfig(), run: run}
+}
+
+// derive returns a stream with the config of s.
+func derive[T, U any](s Stream[T], run func(ctx context.Context, yield func(result.Result[U]) bool)) Stream[U] {
+	return Stream[U]{config: s.config, run: run}
+}
+
+// ok wraps v in an Ok Result, or in an Err holding ErrNilValue when v is nil, which result.Ok rejects.
+func ok[T any](v T) result.Result[T] {
+	if go_utils.IsNil(v) {
+		return result.Err[T](ErrNilValue)
+	}
+	return result.Ok(v)
+}
@@ -0,0 +1,455 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/azat-dev/go-utils/result"
+)
+
+// checkNoLeak fails the test if goroutines started during it are still running when it ends.
+func checkNoLeak(t *testing.T) {
+	t.Helper()
+	before := runtime.NumGoroutine()
+	t.Cleanup(func() {
+		deadline := time.Now().Add(time.Second)
+		for runtime.NumGoroutine() > before {
+			if time.Now().After(deadline) {
+				t.Errorf("Expected %d goroutines, got %d", before, runtime.NumGoroutine())
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+	})
+}
+
+var errOdd = errors.New("odd")
+
+// failOdd returns an Err for odd numbers and Ok(n) otherwise.
+func failOdd(_ context.Context, n int) result.Result[int] {
+	if n%2 != 0 {
+		return result.Err[int](fmt.Errorf("%d: %w", n, errOdd))
+	}
+	return result.Ok(n)
+}
+
+func TestSources(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("FromSlice can be consumed twice", func(t *testing.T) {
+		s := FromSlice([]int{1, 2, 3})
+		for range 2 {
+			if got := Collect(ctx, s).Unwrap(); !slices.Equal(got, []int{1, 2, 3}) { //resultcheck:ignore unwrap
+				t.Errorf("Expected [1 2 3], got %v", got)
+			}
+		}
+	})
+
+	t.Run("FromSeq", func(t *testing.T) {
+		got := Collect(ctx, FromSeq(slices.Values([]string{"a", "b"}))).Unwrap() //resultcheck:ignore unwrap
+		if !slices.Equal(got, []string{"a", "b"}) {
+			t.Errorf("Expected [a b], got %v", got)
+		}
+	})
+
+	t.Run("FromChannel reads until closed", func(t *testing.T) {
+		ch := make(chan int, 3)
+		ch <- 1
+		ch <- 2
+		close(ch)
+		if got := Collect(ctx, FromChannel(ch)).Unwrap(); !slices.Equal(got, []int{1, 2}) { //resultcheck:ignore unwrap
+			t.Errorf("Expected [1 2], got %v", got)
+		}
+	})
+
+	t.Run("Lines", func(t *testing.T) {
+		got := Collect(ctx, Lines(strings.NewReader("one\r\ntwo\n\nthree"))).Unwrap() //resultcheck:ignore unwrap
+		if !slices.Equal(got, []string{"one", "two", "", "three"}) {
+			t.Errorf("Expected [one two  three], got %q", got)
+		}
+	})
+
+	t.Run("Lines read error", func(t *testing.T) {
+		long := strings.Repeat("x", 70*1024)
+		got := Collect(ctx, Lines(strings.NewReader("ok\n"+long)).WithConfig(Config{Errors: SkipAndLog}))
+		if lines := got.Unwrap(); !slices.Equal(lines, []string{"ok"}) { //resultcheck:ignore unwrap
+			t.Errorf("Expected [ok], got %v", lines)
+		}
+		if _, err := Collect(ctx, Lines(strings.NewReader(long))).Get(); err == nil {
+			t.Error("Expected a token too long error")
+		}
+	})
+
+	t.Run("nil values become Err elements", func(t *testing.T) {
+		v := 1
+		_, err := Collect(ctx, FromSlice([]*int{&v, nil})).Get()
+		if !errors.Is(err, ErrNilValue) {
+			t.Errorf("Expected ErrNilValue, got %v", err)
+		}
+	})
+}
+
+func TestStages(t *testing.T) {
+	ctx := context.Background()
+	numbers := FromSlice([]int{1, 2, 3, 4, 5, 6, 7})
+
+	tests := []struct {
+		name string
+		s    Stream[string]
+		want []string
+	}{
+		{
+			name: "Map",
+			s:    Map(numbers, strconv.Itoa),
+			want: []string{"1", "2", "3", "4", "5", "6", "7"},
+		},
+		{
+			name: "Filter",
+			s:    Map(Filter(numbers, func(n int) bool { return n%3 == 0 }), strconv.Itoa),
+			want: []string{"3", "6"},
+		},
+		{
+			name: "FlatMap",
+			s: FlatMap(FromSlice([]string{"ab", "", "c"}), func(s string) Stream[string] {
+				return FromSlice(strings.Split(s, ""))
+			}),
+			want: []string{"a", "b", "c"},
+		},
+		{
+			name: "Batch",
+			s:    Map(Batch(numbers, 3), sprint),
+			want: []string{"[1 2 3]", "[4 5 6]", "[7]"},
+		},
+		{
+			name: "sliding Window",
+			s:    Map(Window(numbers, 3, 2), sprint),
+			want: []string{"[1 2 3]", "[3 4 5]", "[5 6 7]"},
+		},
+		{
+			name: "tumbling Window drops the partial one",
+			s:    Map(Window(numbers, 2, 2), sprint),
+			want: []string{"[1 2]", "[3 4]", "[5 6]"},
+		},
+		{
+			name: "Window with gaps",
+			s:    Map(Window(numbers, 2, 3), sprint),
+			want: []string{"[1 2]", "[4 5]"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Collect(ctx, tt.s).Get()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	t.Run("Err elements pass through stages in order", func(t *testing.T) {
+		s := Batch(TryMap(numbers, failOdd), 2)
+		var got []string
+		for r := range s.All(ctx) {
+			if v, err := r.Get(); err != nil {
+				got = append(got, err.Error())
+			} else {
+				got = append(got, fmt.Sprint(v))
+			}
+		}
+		want := []string{"1: odd", "[2]", "3: odd", "[4]", "5: odd", "[6]", "7: odd"}
+		if !slices.Equal(got, want) {
+			t.Errorf("Expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("panic in Map becomes an Err element", func(t *testing.T) {
+		s := Map(numbers, func(n int) int { return 10 / (n - 2) })
+		var panicErr *result.PanicError
+		if _, err := Collect(ctx, s).Get(); !errors.As(err, &panicErr) {
+			t.Errorf("Expected *result.PanicError, got %v", err)
+		}
+	})
+
+	t.Run("invalid sizes panic", func(t *testing.T) {
+		for name, f := range map[string]func(){
+			"Batch":  func() { Batch(numbers, 0) },
+			"Window": func() { Window(numbers, 2, 0) },
+		} {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Errorf("Expected %s to panic", name)
+					}
+				}()
+				f()
+			}()
+		}
+	})
+}
+
+func TestErrorPolicies(t *testing.T) {
+	ctx := context.Background()
+	s := TryMap(FromSlice([]int{1, 2, 3, 4}), failOdd)
+
+	t.Run("FailFast stops at the first Err", func(t *testing.T) {
+		var seen []int
+		source := Map(FromSlice([]int{2, 1, 4}), func(n int) int {
+			seen = append(seen, n)
+			return n
+		})
+		_, err := Collect(ctx, TryMap(source, failOdd)).Get()
+		if err == nil || err.Error() != "1: odd" {
+			t.Errorf("Expected '1: odd', got %v", err)
+		}
+		if !slices.Equal(seen, []int{2, 1}) {
+			t.Errorf("Expected the source to stop after 1, got %v", seen)
+		}
+	})
+
+	t.Run("SkipAndLog", func(t *testing.T) {
+		var logged []string
+		config := Config{Errors: SkipAndLog, OnError: func(err error) { logged = append(logged, err.Error()) }}
+		got, err := Collect(ctx, s.WithConfig(config)).Get()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(got, []int{2, 4}) {
+			t.Errorf("Expected [2 4], got %v", got)
+		}
+		if !slices.Equal(logged, []string{"1: odd", "3: odd"}) {
+			t.Errorf("Expected both errors to be logged, got %v", logged)
+		}
+	})
+
+	t.Run("CollectErrors", func(t *testing.T) {
+		sum := Reduce(ctx, s.WithConfig(Config{Errors: CollectErrors}), 0, func(acc, n int) int { return acc + n })
+		_, err := sum.Get()
+		if err == nil || err.Error() != "1: odd\n3: odd" {
+			t.Errorf("Expected both errors joined, got %v", err)
+		}
+		if !errors.Is(err, errOdd) {
+			t.Error("Expected errors.Is to match errOdd")
+		}
+	})
+
+	t.Run("Reduce to nil", func(t *testing.T) {
+		appendFn := func(acc []int, n int) []int { return append(acc, n) }
+		r := Reduce(ctx, FromSlice([]int{}), []int(nil), appendFn)
+		if !r.ErrIs(ErrNilValue) {
+			t.Errorf("Expected ErrNilValue, got %v", r)
+		}
+	})
+
+	t.Run("config survives stages", func(t *testing.T) {
+		config := Config{Errors: SkipAndLog}
+		got := Collect(ctx, Map(s.WithConfig(config), strconv.Itoa)).Unwrap() //resultcheck:ignore unwrap
+		if !slices.Equal(got, []string{"2", "4"}) {
+			t.Errorf("Expected [2 4], got %v", got)
+		}
+	})
+
+	t.Run("ForEach error stops regardless of policy", func(t *testing.T) {
+		errStop := errors.New("stop")
+		calls := 0
+		r := ForEach(ctx, s.WithConfig(Config{Errors: SkipAndLog}), func(int) error {
+			calls++
+			return errStop
+		})
+		if _, err := r.Get(); !errors.Is(err, errStop) || calls != 1 {
+			t.Errorf("Expected errStop after 1 call, got %v after %d", err, calls)
+		}
+	})
+
+	t.Run("empty stream", func(t *testing.T) {
+		got, err := Collect(ctx, FromSlice([]int(nil))).Get()
+		if err != nil || got == nil || len(got) != 0 {
+			t.Errorf("Expected an empty non-nil slice, got %#v, %v", got, err)
+		}
+	})
+}
+
+func TestParallelMap(t *testing.T) {
+	checkNoLeak(t)
+	ctx := context.Background()
+
+	t.Run("keeps order and bounds concurrency", func(t *testing.T) {
+		var running, peak atomic.Int32
+		s := ParallelMap(FromSeq(countTo(50)), 4, func(_ context.Context, n int) result.Result[int] {
+			peak.Store(max(peak.Load(), running.Add(1)))
+			time.Sleep(time.Duration(50-n) * 10 * time.Microsecond)
+			running.Add(-1)
+			return result.Ok(n * n)
+		})
+		got := Collect(ctx, s).Unwrap() //resultcheck:ignore unwrap
+		for i, v := range got {
+			if v != i*i {
+				t.Fatalf("Expected %d at %d, got %d", i*i, i, v)
+			}
+		}
+		if len(got) != 50 {
+			t.Errorf("Expected 50 values, got %d", len(got))
+		}
+		if p := peak.Load(); p > 4 {
+			t.Errorf("Expected at most 4 concurrent calls, got %d", p)
+		}
+	})
+
+	t.Run("Err elements and panics", func(t *testing.T) {
+		s := ParallelMap(TryMap(FromSlice([]int{1, 2, 3}), failOdd), 2, func(_ context.Context, n int) result.Result[int] {
+			panic("boom")
+		})
+		var got []string
+		for r := range s.All(ctx) {
+			_, err := r.Get()
+			got = append(got, err.Error())
+		}
+		if len(got) != 3 || got[0] != "1: odd" || !strings.Contains(got[1], "boom") || got[2] != "3: odd" {
+			t.Errorf("Unexpected elements %q", got)
+		}
+	})
+
+	t.Run("FailFast cancels workers", func(t *testing.T) {
+		var canceled atomic.Int32
+		s := ParallelMap(FromSeq(countTo(1000)), 3, func(ctx context.Context, n int) result.Result[int] {
+			if n == 0 {
+				return result.Err[int](errOdd)
+			}
+			<-ctx.Done()
+			canceled.Add(1)
+			return result.Ok(n)
+		})
+		if _, err := Collect(ctx, s).Get(); !errors.Is(err, errOdd) {
+			t.Errorf("Expected errOdd, got %v", err)
+		}
+		if n := canceled.Load(); n > 3 {
+			t.Errorf("Expected at most 3 canceled calls, got %d", n)
+		}
+	})
+
+	t.Run("upstream panic is re-raised", func(t *testing.T) {
+		s := ParallelMap(Map(FromSlice([]int{1}), func(int) int { panic("upstream") }), 2, failOdd)
+		_, err := Collect(ctx, s).Get()
+		if err == nil || !strings.Contains(err.Error(), "upstream") {
+			t.Errorf("Expected the recovered panic as an Err element, got %v", err)
+		}
+
+		source := newStream(func(context.Context, func(result.Result[int]) bool) { panic("source") })
+		defer func() {
+			if v := recover(); v != "source" {
+				t.Errorf("Expected panic 'source', got %v", v)
+			}
+		}()
+		_ = Collect(ctx, ParallelMap(source, 2, failOdd))
+	})
+}
+
+func TestCancellation(t *testing.T) {
+	checkNoLeak(t)
+
+	t.Run("Collect returns the cause", func(t *testing.T) {
+		errShutdown := errors.New("shutdown")
+		ctx, cancel := context.WithCancelCause(context.Background())
+		defer cancel(nil)
+		s := Map(FromSeq(countTo(-1)), func(n int) int {
+			if n == 10 {
+				cancel(errShutdown)
+			}
+			return n
+		})
+		if _, err := Collect(ctx, s.WithConfig(Config{Errors: CollectErrors})).Get(); !errors.Is(err, errShutdown) {
+			t.Errorf("Expected errShutdown, got %v", err)
+		}
+	})
+
+	t.Run("FromChannel stops waiting", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if _, err := Collect(ctx, FromChannel(make(chan int))).Get(); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected DeadlineExceeded, got %v", err)
+		}
+	})
+
+	t.Run("ParallelMap stops waiting", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		s := ParallelMap(FromSeq(countTo(-1)), 4, func(ctx context.Context, n int) result.Result[int] {
+			if n == 20 {
+				cancel()
+			}
+			return result.Ok(n)
+		})
+		if _, err := Collect(ctx, s).Get(); !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected Canceled, got %v", err)
+		}
+	})
+}
+
+func TestBackpressure(t *testing.T) {
+	checkNoLeak(t)
+	ctx := context.Background()
+
+	t.Run("source runs only as far as the consumer", func(t *testing.T) {
+		produced := 0
+		s := Map(FromSeq(countTo(-1)), func(n int) int {
+			produced++
+			return n
+		})
+		for r := range s.All(ctx) {
+			if r.Unwrap() == 4 { //resultcheck:ignore unwrap
+				break
+			}
+		}
+		if produced != 5 {
+			t.Errorf("Expected 5 values produced, got %d", produced)
+		}
+	})
+
+	t.Run("ParallelMap reads a bounded number ahead", func(t *testing.T) {
+		var produced atomic.Int32
+		source := Map(FromSeq(countTo(-1)), func(n int) int {
+			produced.Add(1)
+			return n
+		})
+		s := ParallelMap(source, 2, func(_ context.Context, n int) result.Result[int] { return result.Ok(n) })
+		release := make(chan struct{})
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for range s.All(ctx) {
+				<-release
+				return
+			}
+		}()
+		time.Sleep(20 * time.Millisecond)
+		// One element with the consumer, two waiting in order and one blocked in the producer.
+		if n := produced.Load(); n > 4 {
+			t.Errorf("Expected at most 4 values produced, got %d", n)
+		}
+		close(release)
+		<-done
+	})
+}
+
+func sprint(values []int) string {
+	return fmt.Sprint(values)
+}
+
+// countTo yields 0, 1, ... up to but not including n, or forever if n is negative.
+func countTo(n int) func(func(int) bool) {
+	return func(yield func(int) bool) {
+		for i := 0; n < 0 || i < n; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package stream
+
+import (
+	"context"
+	"errors"
+
+	"github.com/azat-dev/go-utils/result"
+)
+
+// Collect runs the stream and returns its Ok values in order.
+// Err elements are handled according to the stream's Config:
+// FailFast returns the first one, SkipAndLog drops them, and CollectErrors returns all of them joined.
+// If ctx is done before the stream ends, the Err holds context.Cause(ctx).
+// The slice is never nil, even when the stream is empty.
+func Collect[T any](ctx context.Context, s Stream[T]) result.Result[[]T] {
+	values := make([]T, 0)
+	err := consume(ctx, s, func(v T) error {
+		values = append(values, v)
+		return nil
+	})
+	if err != nil {
+		return result.Err[[]T](err)
+	}
+	return result.Ok(values)
+}
+
+// Reduce runs the stream and folds its Ok values into initial with f, handling Err elements like Collect.
+// A nil final accumulator, such as a nil initial slice on an empty stream, gives an Err holding ErrNilValue.
+func Reduce[T, A any](ctx context.Context, s Stream[T], initial A, f func(A, T) A) result.Result[A] {
+	acc := initial
+	err := consume(ctx, s, func(v T) error {
+		acc = f(acc, v)
+		return nil
+	})
+	if err != nil {
+		return result.Err[A](err)
+	}
+	return ok(acc)
+}
+
+// ForEach runs the stream and calls f for every Ok value, handling Err elements like Collect.
+// An error returned by f stops the pipeline and is returned regardless of the error policy.
+func ForEach[T any](ctx context.Context, s Stream[T], f func(T) error) result.Result[struct{}] {
+	if err := consume(ctx, s, f); err != nil {
+		return result.Err[struct{}](err)
+	}
+	return result.Ok(struct{}{})
+}
+
+// consume runs the stream, passes every Ok value to f and applies the error policy to Err elements.
+func consume[T any](ctx context.Context, s Stream[T], f func(T) error) error {
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var stop error
+	var errs []error
+	s.run(runCtx, func(r result.Result[T]) bool {
+		v, err := r.Get()
+		if err == nil {
+			stop = f(v)
+			return stop == nil
+		}
+		if s.config.Errors == FailFast {
+			stop = err
+			return false
+		}
+		if s.config.OnError != nil {
+			s.config.OnError(err)
+		}
+		if s.config.Errors == CollectErrors {
+			errs = append(errs, err)
+		}
+		return true
+	})
+	switch {
+	case stop != nil:
+		return stop
+	case ctx.Err() != nil:
+		return context.Cause(ctx)
+	default:
+		return errors.Join(errs...)
+	}
+}
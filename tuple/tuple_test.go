@@ -0,0 +1,24 @@
+package tuple
+
+import "testing"
+
+func TestPair(t *testing.T) {
+	p := NewPair("a", 1)
+	first, second := p.Unpack()
+	if first != "a" || second != 1 {
+		t.Errorf("Expected ('a', 1), got ('%s', %d)", first, second)
+	}
+
+	swapped := p.Swap()
+	if swapped.First != 1 || swapped.Second != "a" {
+		t.Errorf("Expected (1, 'a'), got (%d, '%s')", swapped.First, swapped.Second)
+	}
+}
+
+func TestTriple(t *testing.T) {
+	tr := NewTriple("a", 1, true)
+	first, second, third := tr.Unpack()
+	if first != "a" || second != 1 || !third {
+		t.Errorf("Expected ('a', 1, true), got ('%s', %d, %t)", first, second, third)
+	}
+}
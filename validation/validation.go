@@ -0,0 +1,269 @@
+package validation
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	go_utils "github.com/azat-dev/go-utils"
+
+	"github.com/azat-dev/go-utils/result"
+)
+
+// FieldError is a single validation failure, located by a path of field names from the validated root.
+// An empty path means the error belongs to the root value itself.
+type FieldError struct {
+	Path []string
+	Err  error
+}
+
+// Field returns the path joined with dots, for example "address.zip".
+func (e FieldError) Field() string {
+	return strings.Join(e.Path, ".")
+}
+
+// Error returns "path: message", or just the message if the path is empty.
+func (e FieldError) Error() string {
+	if len(e.Path) == 0 {
+		return e.Err.Error()
+	}
+	return e.Field() + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationErrors is the list of every failure collected while validating a value.
+// It is the error stored in the Err Result returned by ToResult.
+type ValidationErrors []FieldError
+
+// Error returns all field errors separated by "; ".
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, fieldErr := range e {
+		messages[i] = fieldErr.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap returns the field errors, so errors.Is and errors.As can match any of them.
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, fieldErr := range e {
+		errs[i] = fieldErr
+	}
+	return errs
+}
+
+// Validated is the outcome of validating a value. Unlike result.Result it keeps every error
+// when values are combined, instead of stopping at the first one.
+// The zero Validated is valid and holds the zero value of T.
+type Validated[T any] struct {
+	value T
+	errs  ValidationErrors
+}
+
+// Valid creates a successfully validated value.
+// Panics if the value is nil (for interface and pointer types), like result.Ok.
+func Valid[T any](v T) Validated[T] {
+	if go_utils.IsNil(v) {
+		panic("Valid() called with nil value")
+	}
+	return Validated[T]{value: v, errs: nil}
+}
+
+// Invalid creates a failed validation holding the given errors.
+// Panics if no errors are given, since an Invalid value must explain why.
+func Invalid[T any](errs ...FieldError) Validated[T] {
+	if len(errs) == 0 {
+		panic("Invalid() called without errors")
+	}
+	return invalid[T](errs)
+}
+
+// Fail creates a failed validation with a single error for the given field path.
+func Fail[T any](err error, path ...string) Validated[T] {
+	return Invalid[T](FieldError{Path: path, Err: err})
+}
+
+// FromResult converts a Result into a Validated value.
+// If the error is (or wraps) ValidationErrors its field errors are kept, otherwise it becomes a root error.
+// A zero Result of a nil-able T holds no value, so it gives a root error holding result.ErrNilValue.
+func FromResult[T any](r result.Result[T]) Validated[T] {
+	v, err := r.Get()
+	if err == nil && go_utils.IsNil(v) {
+		return Fail[T](result.ErrNilValue)
+	}
+	if err == nil {
+		return Valid(v)
+	}
+	var errs ValidationErrors
+	if errors.As(err, &errs) && len(errs) > 0 {
+		return Invalid[T](errs...)
+	}
+	return Fail[T](err)
+}
+
+// Field converts a Result into a Validated value and places its errors under the given field name.
+func Field[T any](name string, r result.Result[T]) Validated[T] {
+	return At(name, FromResult(r))
+}
+
+// At prefixes the path of every error in v with the given field name.
+// Use it to nest the validation of a sub-structure under its field.
+func At[T any](name string, v Validated[T]) Validated[T] {
+	if v.errs == nil {
+		return v
+	}
+	errs := make(ValidationErrors, len(v.errs))
+	for i, fieldErr := range v.errs {
+		path := make([]string, 0, len(fieldErr.Path)+1)
+		path = append(path, name)
+		path = append(path, fieldErr.Path...)
+		errs[i] = FieldError{Path: path, Err: fieldErr.Err}
+	}
+	return Validated[T]{value: v.value, errs: errs}
+}
+
+// IsValid returns true if validation succeeded.
+func (v Validated[T]) IsValid() bool {
+	return v.errs == nil
+}
+
+// IsInvalid returns true if validation failed.
+func (v Validated[T]) IsInvalid() bool {
+	return v.errs != nil
+}
+
+// Get returns the value and nil if validation succeeded. Otherwise, it returns the zero-value and the errors.
+func (v Validated[T]) Get() (T, ValidationErrors) {
+	return v.value, v.errs
+}
+
+// Errors returns the collected errors, or nil if validation succeeded.
+func (v Validated[T]) Errors() ValidationErrors {
+	return v.errs
+}
+
+// ToResult converts the Validated value into a Result.
+// A failed validation becomes an Err holding ValidationErrors, and a nil value,
+// as in the zero Validated of a nil-able T, an Err holding result.ErrNilValue.
+func (v Validated[T]) ToResult() result.Result[T] {
+	if v.errs != nil {
+		return result.Err[T](v.errs)
+	}
+	return result.From(v.value, nil)
+}
+
+// Map applies a function to the value if validation succeeded, keeping the errors otherwise.
+func Map[T, U any](v Validated[T], f func(T) U) Validated[U] {
+	if v.errs != nil {
+		return invalid[U](v.errs)
+	}
+	return Valid(f(v.value))
+}
+
+// Apply applies a validated function to a validated value, collecting the errors of both.
+func Apply[T, U any](vf Validated[func(T) U], vt Validated[T]) Validated[U] {
+	if errs := collect(vf.errs, vt.errs); errs != nil {
+		return invalid[U](errs)
+	}
+	return Valid(vf.value(vt.value))
+}
+
+// Sequence turns a list of validated values into a validated list.
+// The errors of each element are placed under its index, so the path of the
+// second element's "name" error is "1.name".
+func Sequence[T any](vs []Validated[T]) Validated[[]T] {
+	values := make([]T, 0, len(vs))
+	var errs ValidationErrors
+	for i, v := range vs {
+		if v.errs != nil {
+			errs = append(errs, At(strconv.Itoa(i), v).errs...)
+			continue
+		}
+		values = append(values, v.value)
+	}
+	if errs != nil {
+		return invalid[[]T](errs)
+	}
+	return Valid(values)
+}
+
+// Map2 combines two validated values with f, or collects the errors of all of them.
+func Map2[A, B, R any](a Validated[A], b Validated[B], f func(A, B) R) Validated[R] {
+	if errs := collect(a.errs, b.errs); errs != nil {
+		return invalid[R](errs)
+	}
+	return Valid(f(a.value, b.value))
+}
+
+// Map3 combines three validated values with f, or collects the errors of all of them.
+func Map3[A, B, C, R any](a Validated[A], b Validated[B], c Validated[C], f func(A, B, C) R) Validated[R] {
+	if errs := collect(a.errs, b.errs, c.errs); errs != nil {
+		return invalid[R](errs)
+	}
+	return Valid(f(a.value, b.value, c.value))
+}
+
+// Map4 combines four validated values with f, or collects the errors of all of them.
+func Map4[A, B, C, D, R any](
+	a Validated[A],
+	b Validated[B],
+	c Validated[C],
+	d Validated[D],
+	f func(A, B, C, D) R,
+) Validated[R] {
+	if errs := collect(a.errs, b.errs, c.errs, d.errs); errs != nil {
+		return invalid[R](errs)
+	}
+	return Valid(f(a.value, b.value, c.value, d.value))
+}
+
+// Map5 combines five validated values with f, or collects the errors of all of them.
+func Map5[A, B, C, D, E, R any](
+	a Validated[A],
+	b Validated[B],
+	c Validated[C],
+	d Validated[D],
+	e Validated[E],
+	f func(A, B, C, D, E) R,
+) Validated[R] {
+	if errs := collect(a.errs, b.errs, c.errs, d.errs, e.errs); errs != nil {
+		return invalid[R](errs)
+	}
+	return Valid(f(a.value, b.value, c.value, d.value, e.value))
+}
+
+// Map6 combines six validated values with f, or collects the errors of all of them.
+func Map6[A, B, C, D, E, F, R any](
+	a Validated[A],
+	b Validated[B],
+	c Validated[C],
+	d Validated[D],
+	e Validated[E],
+	g Validated[F],
+	f func(A, B, C, D, E, F) R,
+) Validated[R] {
+	if errs := collect(a.errs, b.errs, c.errs, d.errs, e.errs, g.errs); errs != nil {
+		return invalid[R](errs)
+	}
+	return Valid(f(a.value, b.value, c.value, d.value, e.value, g.value))
+}
+
+// invalid creates a failed validation without checking that errs is non-empty.
+func invalid[T any](errs ValidationErrors) Validated[T] {
+	var zero T
+	return Validated[T]{value: zero, errs: errs}
+}
+
+// collect concatenates error lists in order. It returns nil if all of them are empty.
+func collect(lists ...ValidationErrors) ValidationErrors {
+	var errs ValidationErrors
+	for _, list := range lists {
+		errs = append(errs, list...)
+	}
+	return errs
+}
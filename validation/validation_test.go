@@ -0,0 +1,192 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/azat-dev/go-utils/result"
+)
+
+var (
+	errEmpty    = errors.New("must not be empty")
+	errNegative = errors.New("must not be negative")
+)
+
+type user struct {
+	Name string
+	Age  int
+}
+
+func validateName(name string) result.Result[string] {
+	if name == "" {
+		return result.Err[string](errEmpty)
+	}
+	return result.Ok(name)
+}
+
+func validateAge(age int) result.Result[int] {
+	if age < 0 {
+		return result.Err[int](errNegative)
+	}
+	return result.Ok(age)
+}
+
+func validateUser(name string, age int) Validated[user] {
+	return Map2(
+		Field("name", validateName(name)),
+		Field("age", validateAge(age)),
+		func(name string, age int) user { return user{Name: name, Age: age} },
+	)
+}
+
+func TestMap2(t *testing.T) {
+	t.Run("all valid", func(t *testing.T) {
+		v := validateUser("alice", 30)
+		if !v.IsValid() {
+			t.Fatalf("Expected valid user, got %v", v.Errors())
+		}
+		u, errs := v.Get()
+		if errs != nil {
+			t.Error("Expected Get to return nil errors")
+		}
+		if u.Name != "alice" || u.Age != 30 {
+			t.Errorf("Unexpected user %+v", u)
+		}
+	})
+
+	t.Run("collects every error", func(t *testing.T) {
+		v := validateUser("", -1)
+		if !v.IsInvalid() {
+			t.Fatal("Expected invalid user")
+		}
+		errs := v.Errors()
+		if len(errs) != 2 {
+			t.Fatalf("Expected 2 errors, got %d", len(errs))
+		}
+		if errs[0].Field() != "name" || errs[0].Err != errEmpty {
+			t.Errorf("Unexpected first error %v", errs[0])
+		}
+		if errs[1].Field() != "age" || errs[1].Err != errNegative {
+			t.Errorf("Unexpected second error %v", errs[1])
+		}
+		expectedMsg := "name: must not be empty; age: must not be negative"
+		if errs.Error() != expectedMsg {
+			t.Errorf("Expected message '%s', got '%s'", expectedMsg, errs.Error())
+		}
+	})
+}
+
+func TestMap6(t *testing.T) {
+	v := Map6(
+		Valid(1), Fail[int](errNegative, "b"), Valid(3), Valid(4), Fail[int](errEmpty, "e"), Valid(6),
+		func(a, b, c, d, e, f int) int { return a + b + c + d + e + f },
+	)
+	errs := v.Errors()
+	if len(errs) != 2 || errs[0].Field() != "b" || errs[1].Field() != "e" {
+		t.Errorf("Unexpected errors %v", errs)
+	}
+
+	sum := Map6(Valid(1), Valid(2), Valid(3), Valid(4), Valid(5), Valid(6),
+		func(a, b, c, d, e, f int) int { return a + b + c + d + e + f },
+	)
+	if value, _ := sum.Get(); value != 21 {
+		t.Errorf("Expected 21, got %d", value)
+	}
+}
+
+func TestApply(t *testing.T) {
+	double := Valid(func(n int) int { return n * 2 })
+	if value, _ := Apply(double, Valid(4)).Get(); value != 8 {
+		t.Errorf("Expected 8, got %d", value)
+	}
+
+	failed := Apply(Fail[func(int) int](errEmpty, "f"), Fail[int](errNegative, "n"))
+	if len(failed.Errors()) != 2 {
+		t.Errorf("Expected errors of both sides, got %v", failed.Errors())
+	}
+}
+
+func TestSequence(t *testing.T) {
+	t.Run("all valid", func(t *testing.T) {
+		v := Sequence([]Validated[int]{Valid(1), Valid(2)})
+		values, errs := v.Get()
+		if errs != nil || len(values) != 2 || values[0] != 1 || values[1] != 2 {
+			t.Errorf("Unexpected result %v %v", values, errs)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		values := Sequence[int](nil).ToResult().Unwrap()
+		if len(values) != 0 {
+			t.Errorf("Expected empty slice, got %v", values)
+		}
+	})
+
+	t.Run("errors are placed under the index", func(t *testing.T) {
+		users := []Validated[user]{validateUser("alice", 1), validateUser("", 2)}
+		errs := At("users", Sequence(users)).Errors()
+		if len(errs) != 1 {
+			t.Fatalf("Expected 1 error, got %v", errs)
+		}
+		if errs[0].Field() != "users.1.name" {
+			t.Errorf("Expected path 'users.1.name', got '%s'", errs[0].Field())
+		}
+	})
+}
+
+func TestResultConversion(t *testing.T) {
+	t.Run("valid to Ok", func(t *testing.T) {
+		r := Valid("x").ToResult()
+		if value := r.UnwrapOr(""); value != "x" {
+			t.Errorf("Expected 'x', got '%s'", value)
+		}
+	})
+
+	t.Run("invalid to Err with ValidationErrors", func(t *testing.T) {
+		r := validateUser("", -1).ToResult()
+		errs := result.ErrAs[ValidationErrors](r)
+		if errs.IsNone() || len(errs.Unwrap()) != 2 {
+			t.Errorf("Expected ValidationErrors with 2 errors, got %v", r.UnwrapErr())
+		}
+		if !r.ErrIs(errEmpty) || !r.ErrIs(errNegative) {
+			t.Error("Expected errors.Is to match every field error")
+		}
+	})
+
+	t.Run("Err with ValidationErrors keeps fields", func(t *testing.T) {
+		nested := At("profile", FromResult(validateUser("", 1).ToResult()))
+		errs := nested.Errors()
+		if len(errs) != 1 || errs[0].Field() != "profile.name" {
+			t.Errorf("Unexpected errors %v", errs)
+		}
+	})
+
+	t.Run("plain Err becomes root error", func(t *testing.T) {
+		errs := FromResult(result.Err[int](errEmpty)).Errors()
+		if len(errs) != 1 || len(errs[0].Path) != 0 || errs[0].Error() != "must not be empty" {
+			t.Errorf("Unexpected errors %v", errs)
+		}
+	})
+}
+
+func TestZero(t *testing.T) {
+	v := FromResult(result.Result[*int]{})
+	if errs := v.Errors(); len(errs) != 1 || !errors.Is(errs[0], result.ErrNilValue) {
+		t.Errorf("Expected a root ErrNilValue for the zero Result, got %v", errs)
+	}
+	if r := (Validated[*int]{}).ToResult(); !r.ErrIs(result.ErrNilValue) {
+		t.Errorf("Expected ErrNilValue for the zero Validated, got %v", r)
+	}
+	if r := (Validated[int]{}).ToResult(); r.UnwrapOr(-1) != 0 {
+		t.Errorf("Expected Ok(0) for the zero Validated, got %v", r)
+	}
+}
+
+func TestInvalidWithoutErrors(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected Invalid() without errors to panic")
+		}
+	}()
+	Invalid[int]()
+}